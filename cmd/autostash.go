@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"gfl/utils"
+	"gfl/utils/strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addAutostashFlag 为会切换分支或 rebase 的命令添加 --autostash，不带值时为 carry
+func addAutostashFlag(cmd *cobra.Command) {
+	cmd.Flags().String("autostash", "", strings.GetPath("autostash.flag"))
+	cmd.Flags().Lookup("autostash").NoOptDefVal = utils.AutostashCarry
+}
+
+// getAutostashMode 返回 --autostash 的值，未指定时使用配置 autostash，取值无效时提示并返回 false
+func getAutostashMode(cmd *cobra.Command, config *utils.YamlConfig) (string, bool) {
+	mode, _ := cmd.Flags().GetString("autostash")
+	if mode == "" && config != nil {
+		mode = config.Autostash
+	}
+	if mode == "" {
+		return utils.AutostashOff, true
+	}
+	if !utils.IsValidAutostashMode(mode) {
+		utils.Error(strings.GetPath("autostash.invalid_mode", mode))
+		return "", false
+	}
+	return mode, true
+}
+
+// runWithAutostash 暂存未提交的更改后执行 run（切换分支或 rebase），再按模式恢复更改：
+//   - carry: 在目标分支上恢复刚暂存的更改
+//   - branch: 更改留给原分支，切换回原分支时恢复；同时恢复目标分支之前暂存的更改
+//
+// run 失败时若仍在原分支上，则把更改恢复到原分支；rebase 因冲突中断时保留暂存
+func runWithAutostash(mode string, run func() bool) bool {
+	if mode == utils.AutostashOff {
+		return run()
+	}
+
+	from, err := utils.GetCurrentBranch()
+	if err != nil {
+		utils.Errorf(strings.GetPath("autostash.current_branch_error", err))
+		return false
+	}
+	stashed, err := utils.StashChanges(from)
+	if err != nil {
+		utils.Errorf(strings.GetPath("autostash.stash_failed", err))
+		return false
+	}
+	if stashed {
+		utils.Info(strings.GetPath("autostash.stashed", from))
+	}
+
+	if !run() {
+		if !stashed {
+			return false
+		}
+		current, _ := utils.GetCurrentBranch()
+		if current == from && !utils.IsRebaseInProgress() {
+			restoreAutostash(from)
+		} else if ref, found := utils.FindAutostash(from); found {
+			utils.Warning(strings.GetPath("autostash.kept", from, ref))
+		}
+		return false
+	}
+
+	to, _ := utils.GetCurrentBranch()
+	switch {
+	case mode == utils.AutostashCarry || to == from:
+		if stashed {
+			restoreAutostash(from)
+		}
+	case mode == utils.AutostashBranch:
+		if stashed {
+			utils.Info(strings.GetPath("autostash.left_for_branch", from))
+		}
+		restoreAutostash(to)
+	}
+	return true
+}
+
+// restoreAutostash 恢复分支的 gfl 暂存，出现冲突时说明暂存仍然保留
+func restoreAutostash(branch string) {
+	ref, err := utils.PopAutostash(branch)
+	if ref == "" {
+		return
+	}
+	if err != nil {
+		utils.Errorf(strings.GetPath("autostash.conflict", branch, err))
+		utils.Warning(strings.GetPath("autostash.conflict_hint", ref))
+		return
+	}
+	utils.Success(strings.GetPath("autostash.restored", branch))
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"gfl/utils"
+	"gfl/utils/strings"
+	"os"
+	str "strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// branchesCmd represents the branches command
+var branchesCmd = &cobra.Command{
+	Use:     "branches",
+	Aliases: []string{"br"},
+	Short:   "Show a dashboard of all branches (alias: br)", // Will be updated after strings load
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := utils.ReadConfig()
+		if config == nil {
+			return
+		}
+
+		mine, _ := cmd.Flags().GetBool("mine")
+		remote, _ := cmd.Flags().GetBool("remote")
+		branchType, _ := cmd.Flags().GetString("type")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		if mine && config.Nickname == "" {
+			utils.Error(strings.GetPath("branches.nickname_required"))
+			return
+		}
+		// --type 支持分支类型的别名（如 hf），release 为发布分支
+		if branchType != "" && branchType != utils.BranchTypeRelease {
+			if resolved, found := utils.GetBranchType(config, branchType); found {
+				branchType = resolved.Name
+			}
+		}
+
+		statuses, err := utils.GetBranchStatuses(config, remote)
+		if err != nil {
+			utils.Errorf(strings.GetPath("branches.list_error", err))
+			return
+		}
+
+		// 按昵称和类型筛选
+		filtered := []utils.BranchStatus{}
+		for _, status := range statuses {
+			if mine && status.Nickname != config.Nickname {
+				continue
+			}
+			if branchType != "" && status.Type != branchType {
+				continue
+			}
+			filtered = append(filtered, status)
+		}
+
+		// JSON 输出，方便脚本使用
+		if asJSON {
+			data, err := json.MarshalIndent(filtered, "", "  ")
+			if err != nil {
+				utils.Errorf(strings.GetPath("branches.list_error", err))
+				return
+			}
+			fmt.Println(string(data))
+			return
+		}
+
+		if len(filtered) == 0 {
+			utils.Info(strings.GetPath("branches.none"))
+			return
+		}
+		renderBranchesTable(config, filtered, remote)
+
+		// 提示进行中的发布分支和基于生产分支的分支
+		var inFlight []string
+		for _, status := range filtered {
+			if status.InFlight {
+				inFlight = append(inFlight, status.Name)
+			}
+		}
+		if len(inFlight) > 0 {
+			utils.Warning(strings.GetPath("branches.in_flight_summary", len(inFlight), str.Join(inFlight, ", ")))
+		}
+	},
+}
+
+// renderBranchesTable 以表格形式显示分支概览
+func renderBranchesTable(config *utils.YamlConfig, statuses []utils.BranchStatus, remote bool) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	if remote {
+		t.SetTitle(strings.GetPath("branches.remote_title"))
+	} else {
+		t.SetTitle(strings.GetPath("branches.title"))
+	}
+	t.SetStyle(table.StyleRounded)
+
+	header := color.New(color.FgCyan, color.Bold)
+	t.AppendHeader(table.Row{
+		header.Sprint(strings.GetPath("branches.column_branch")),
+		header.Sprint(strings.GetPath("branches.column_type")),
+		header.Sprint(strings.GetPath("branches.column_nickname")),
+		header.Sprint(strings.GetPath("branches.column_upstream")),
+		header.Sprint(strings.GetPath("branches.column_base", config.DevBaseBranch)),
+		header.Sprint(strings.GetPath("branches.column_age")),
+		header.Sprint(strings.GetPath("branches.column_merged")),
+		header.Sprint(strings.GetPath("branches.column_in_flight")),
+	})
+
+	for _, status := range statuses {
+		branch := status.Name
+		if status.Current {
+			branch = color.New(color.FgGreen, color.Bold).Sprint("* " + branch)
+		}
+
+		t.AppendRow(table.Row{
+			branch,
+			valueOrDash(status.Type),
+			valueOrDash(status.Nickname),
+			formatUpstream(status),
+			formatBasePosition(status),
+			utils.FormatAge(status.LastCommit),
+			formatMergedStatus(status),
+			formatInFlight(status),
+		})
+	}
+
+	t.Render()
+}
+
+// formatUpstream 显示上游分支及未推送/未拉取的提交数，远程分支没有上游
+func formatUpstream(status utils.BranchStatus) string {
+	switch {
+	case status.Remote:
+		return "-"
+	case status.Upstream == "":
+		return color.YellowString(strings.GetPath("branches.not_pushed"))
+	case status.UpstreamGone:
+		return color.RedString(strings.GetPath("branches.upstream_gone", status.Upstream))
+	}
+	upstream := status.Upstream
+	if status.UpstreamAhead > 0 {
+		upstream += color.YellowString(" ↑%d", status.UpstreamAhead)
+	}
+	if status.UpstreamBehind > 0 {
+		upstream += color.CyanString(" ↓%d", status.UpstreamBehind)
+	}
+	return upstream
+}
+
+// formatBasePosition 显示相对 devBaseBranch 领先/落后的提交数
+func formatBasePosition(status utils.BranchStatus) string {
+	if status.Base == "" {
+		return "-"
+	}
+	return fmt.Sprintf("↑%d ↓%d", status.Ahead, status.Behind)
+}
+
+// formatMergedStatus 显示分支合并到的基础分支，只按内容判断的合并以黄色显示
+func formatMergedStatus(status utils.BranchStatus) string {
+	if status.MergedInto == "" {
+		return "-"
+	}
+	merged := fmt.Sprintf("%s (%s)", status.MergedInto, strings.GetPath("sweep.merge_kind."+status.MergeKind))
+	if status.MergeConfidence == utils.MergeConfidenceMedium {
+		return color.YellowString(merged + "?")
+	}
+	return color.MagentaString(merged)
+}
+
+// formatInFlight 标记尚未合并到生产分支的发布分支和基于生产分支的分支（如热修复），
+// 自定义类型没有专门的文字时使用通用标记
+func formatInFlight(status utils.BranchStatus) string {
+	if !status.InFlight {
+		return "-"
+	}
+	label := strings.GetPath("branches.in_flight." + status.Type)
+	if label == "" {
+		label = strings.GetPath("branches.in_flight.default")
+	}
+	return color.New(color.FgRed, color.Bold).Sprint(label)
+}
+
+// valueOrDash 空值显示为 -
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(branchesCmd)
+
+	branchesCmd.Flags().BoolP("mine", "m", false, strings.GetPath("branches.mine_flag"))
+	branchesCmd.Flags().BoolP("remote", "r", false, strings.GetPath("branches.remote_flag"))
+	branchesCmd.Flags().StringP("type", "t", "", strings.GetPath("branches.type_flag"))
+	branchesCmd.Flags().Bool("json", false, strings.GetPath("branches.json_flag"))
+}
@@ -4,15 +4,16 @@ import (
 	"fmt"
 	"gfl/utils"
 	"gfl/utils/strings"
+	str "strings"
 
 	"github.com/spf13/cobra"
 )
 
 var bugfixCmd = &cobra.Command{
-	Use:     "bugfix [bug-name]",
+	Use:     "bugfix [bug-name...]",
 	Short:   strings.GetPath("bugfix.short"),
 	Aliases: []string{"b", "fix"},
-	Args:    cobra.ExactArgs(1), // 要求提供一个参数
+	Args:    cobra.MinimumNArgs(1), // 多个参数会以空格拼接为名称
 	Run: func(cmd *cobra.Command, args []string) {
 		config := utils.ReadConfig()
 
@@ -20,9 +21,17 @@ var bugfixCmd = &cobra.Command{
 			return
 		}
 
-		bugName := args[0]
-		branchName := utils.GenerateBranchName(config, "fix", bugName)
-		baseRemoteBranch := fmt.Sprintf("origin/%s", config.DevBaseBranch)
+		// 如果名称是 issue 引用（#123 / GH-123），根据 issue 标题生成名称
+		bugName, issue, ok := resolveIssueName(config, str.Join(args, " "))
+		if !ok {
+			return
+		}
+		branchName, ok := sanitizeBranchName(config, buildBranchName(config, "fix", bugName, issue))
+		if !ok {
+			return
+		}
+		fixType, _ := utils.GetBranchType(config, "fix")
+		baseRemoteBranch := fmt.Sprintf("origin/%s", fixType.Base)
 
 		// 执行命令: git fetch origin
 		fetchCmd := "git fetch origin"
@@ -35,6 +44,7 @@ var bugfixCmd = &cobra.Command{
 		if err := utils.RunCommandWithSpin(checkoutCmd, strings.GetPath("bugfix.creating")); err != nil {
 			return
 		}
+		recordBranchIssue(branchName, issue)
 
 		utils.Successf(strings.GetPath("bugfix.success", "bugfix", branchName))
 	},
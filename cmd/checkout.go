@@ -2,38 +2,84 @@ package cmd
 
 import (
 	"gfl/utils"
+	"gfl/utils/strings"
 
 	"github.com/spf13/cobra"
 )
 
 // checkoutCmd represents the co command
 var checkoutCmd = &cobra.Command{
-	Use:     "checkout [filter]",
+	Use:     "checkout [filter|-]",
 	Aliases: []string{"co"},
 	Short:   "Interactive git branch switching (alias: co)", // Will be updated after strings load
+	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		// Get branches with optional filter
-		var branches []string
+		config := utils.ReadConfig()
+		mode, ok := getAutostashMode(cmd, config)
+		if !ok {
+			return
+		}
+
+		// gfl co - 切换回上一个分支
+		if len(args) > 0 && args[0] == "-" {
+			runWithAutostash(mode, utils.CheckoutPreviousBranch)
+			return
+		}
+
+		// Get branches with optional fuzzy filter
+		filter := ""
 		if len(args) > 0 {
-			branches = utils.GetLocalBranches(args[0])
-		} else {
-			branches = utils.GetLocalBranches()
+			filter = args[0]
+		}
+		if currentBranch, _ := utils.GetCurrentBranch(); filter != "" && filter == currentBranch {
+			utils.Info(strings.GetPath("checkout.already_on", filter))
+			return
+		}
+		choices, err := utils.ListBranchChoices(filter)
+		if err != nil {
+			utils.Errorf(strings.GetPath("checkout.list_error", err))
+			return
+		}
+
+		var choice utils.BranchChoice
+		exact, isExact := findExactChoice(choices, filter)
+		switch {
+		case len(choices) == 0:
+			utils.Info(strings.GetPath("checkout.no_match", filter))
+			return
+		case isExact:
+			// 与分支名完全相同时直接切换
+			choice = exact
+		case len(choices) == 1 && filter != "":
+			// 过滤后只剩一个分支时直接切换
+			choice = choices[0]
+		default:
+			base := ""
+			if config != nil {
+				base = utils.DevBaseRef(config, false)
+			}
+			if choice, ok = utils.BuildCommandList(choices, base); !ok {
+				return
+			}
 		}
 
-		utils.BuildCommandList(branches)
+		runWithAutostash(mode, func() bool {
+			return utils.CheckoutBranchChoice(choice)
+		})
 	},
 }
 
+// findExactChoice 查找名称与 filter 完全相同的分支（远程分支也可写作 origin/<分支>）
+func findExactChoice(choices []utils.BranchChoice, filter string) (utils.BranchChoice, bool) {
+	for _, choice := range choices {
+		if choice.Name == filter || (choice.RemoteOnly && "origin/"+choice.Name == filter) {
+			return choice, true
+		}
+	}
+	return utils.BranchChoice{}, false
+}
+
 func init() {
 	rootCmd.AddCommand(checkoutCmd)
-
-	// Here you will define your flags and configuration settings.
-
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// coCmd.PersistentFlags().String("foo", "", "A help for foo")
-
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// coCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	addAutostashFlag(checkoutCmd)
 }
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"gfl/utils"
 	"gfl/utils/strings"
 	"os"
+	"reflect"
+	str "strings"
 
 	"github.com/afeiship/go-box"
 	"github.com/fatih/color"
@@ -35,63 +38,38 @@ var configCmd = &cobra.Command{
 			color.New(color.FgMagenta, color.Bold).Sprint(strings.GetPath("config.source")),
 		})
 
-		// 确定每个配置项的来源
-		getSource := func(field string) string {
-			// 检查各个配置文件（按优先级从高到低）
-			for i := len(configInfo.Sources) - 1; i >= 0; i-- {
-				source := configInfo.Sources[i]
-				if !source.Exists {
-					continue
-				}
-
-				switch field {
-				case "debug":
-					if source.Config.DebugSet {
-						return source.Name
-					}
-				case "devBaseBranch":
-					if source.Config.DevBaseBranchSet {
-						return source.Name
-					}
-				case "productionBranch":
-					if source.Config.ProductionBranchSet {
-						return source.Name
-					}
-				case "nickname":
-					if source.Config.NicknameSet {
-						return source.Name
-					}
-				case "featurePrefix":
-					if source.Config.FeaturePrefixSet {
-						return source.Name
-					}
-				case "fixPrefix":
-					if source.Config.FixPrefixSet {
-						return source.Name
-					}
-				case "hotfixPrefix":
-					if source.Config.HotfixPrefixSet {
-						return source.Name
-					}
-				case "branchCaseFormat":
-					if source.Config.BranchCaseFormatSet {
-						return source.Name
-					}
-				}
-			}
-
-			return strings.GetPath("config.default_value")
-		}
-
-		// 辅助函数：为来源添加颜色
+		// 确定每个配置项的来源，逐项合并的值（如 branchTypes）列出所有来源
+		getSource := func(field utils.ConfigField) string {
+			sources := configInfo.SourcesOf(field.Key)
+			if len(sources) == 0 {
+				return strings.GetPath("config.default_value")
+			}
+			if field.Type.Kind() != reflect.Map && field.Type.Kind() != reflect.Struct {
+				return sources[len(sources)-1]
+			}
+			return str.Join(sources, " + ")
+		}
+
+		// 辅助函数：为来源添加颜色（按优先级最高的来源着色）
 		colorizeSource := func(source string) string {
-			switch source {
+			names := str.Split(source, " + ")
+			switch names[len(names)-1] {
+			case strings.GetPath("config.env_config"):
+				return color.New(color.FgHiRed, color.Bold).Sprint(source)
+			case strings.GetPath("config.git_repo_config"), strings.GetPath("config.git_global_config"):
+				return color.New(color.FgHiMagenta, color.Bold).Sprint(source)
 			case strings.GetPath("config.custom_config"):
 				return color.New(color.FgRed, color.Bold).Sprint(source)
 			case strings.GetPath("config.local_config"):
 				return color.New(color.FgYellow, color.Bold).Sprint(source)
 			case strings.GetPath("config.global_config"):
 				return color.New(color.FgBlue, color.Bold).Sprint(source)
+			case strings.GetPath("config.user_config"):
+				return color.New(color.FgGreen, color.Bold).Sprint(source)
+			case strings.GetPath("config.directory_config"):
+				return color.New(color.FgMagenta, color.Bold).Sprint(source)
+			case strings.GetPath("config.inherited_config"):
+				return color.New(color.FgHiCyan, color.Bold).Sprint(source)
 			case strings.GetPath("config.default_value"):
 				return color.New(color.FgCyan).Sprint(source)
 			default:
@@ -101,73 +79,47 @@ var configCmd = &cobra.Command{
 
 		// 辅助函数：为值添加颜色
 		colorizeValue := func(value string, source string) string {
-			switch source {
+			names := str.Split(source, " + ")
+			switch names[len(names)-1] {
+			case strings.GetPath("config.env_config"):
+				return color.New(color.FgHiRed).Sprint(value)
+			case strings.GetPath("config.git_repo_config"), strings.GetPath("config.git_global_config"):
+				return color.New(color.FgHiMagenta).Sprint(value)
 			case strings.GetPath("config.custom_config"):
 				return color.New(color.FgRed).Sprint(value)
 			case strings.GetPath("config.local_config"):
 				return color.New(color.FgYellow).Sprint(value)
 			case strings.GetPath("config.global_config"):
 				return color.New(color.FgBlue).Sprint(value)
+			case strings.GetPath("config.user_config"):
+				return color.New(color.FgGreen).Sprint(value)
+			case strings.GetPath("config.directory_config"):
+				return color.New(color.FgMagenta).Sprint(value)
+			case strings.GetPath("config.inherited_config"):
+				return color.New(color.FgHiCyan).Sprint(value)
 			default:
 				return value
 			}
 		}
 
-		debugSource := getSource("debug")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.debug_mode"),
-			fmt.Sprintf("%v", finalConfig.Debug),
-			colorizeSource(debugSource),
-		})
-
-		devBaseSource := getSource("devBaseBranch")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.develop_base_branch"),
-			colorizeValue(finalConfig.DevBaseBranch, devBaseSource),
-			colorizeSource(devBaseSource),
-		})
-
-		prodSource := getSource("productionBranch")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.production_branch"),
-			colorizeValue(finalConfig.ProductionBranch, prodSource),
-			colorizeSource(prodSource),
-		})
-
-		nicknameSource := getSource("nickname")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.nickname"),
-			colorizeValue(finalConfig.Nickname, nicknameSource),
-			colorizeSource(nicknameSource),
-		})
-
-		featureSource := getSource("featurePrefix")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.feature_prefix"),
-			colorizeValue(finalConfig.FeaturePrefix, featureSource),
-			colorizeSource(featureSource),
-		})
-
-		fixSource := getSource("fixPrefix")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.fix_prefix"),
-			colorizeValue(finalConfig.FixPrefix, fixSource),
-			colorizeSource(fixSource),
-		})
-
-		hotfixSource := getSource("hotfixPrefix")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.hotfix_prefix"),
-			colorizeValue(finalConfig.HotfixPrefix, hotfixSource),
-			colorizeSource(hotfixSource),
-		})
+		// 按照配置结构体的声明顺序逐项显示
+		for _, field := range utils.ConfigFields() {
+			source := getSource(field)
+			value := utils.FormatConfigValue(field.Value(&finalConfig))
+			if field.Empty != "" && (value == "" || value == "0") {
+				value = strings.GetPath(field.Empty)
+			}
 
-		caseFormatSource := getSource("branchCaseFormat")
-		t.AppendRow(table.Row{
-			strings.GetPath("config.branch_case_format"),
-			colorizeValue(finalConfig.BranchCaseFormat, caseFormatSource),
-			colorizeSource(caseFormatSource),
-		})
+			label := field.Key
+			if field.Label != "" {
+				label = strings.GetPath(field.Label)
+			}
+			t.AppendRow(table.Row{
+				label,
+				colorizeValue(value, source),
+				colorizeSource(source),
+			})
+		}
 
 		t.AppendSeparator()
 		exampleBranch := utils.GenerateBranchName(&finalConfig, "feature", "new-feature")
@@ -180,9 +132,9 @@ var configCmd = &cobra.Command{
 		t.Render()
 
 		// 2. 显示配置来源详情 - 简化列表格式
-		fmt.Printf(strings.GetPath("config.config_sources_title"))
+		fmt.Print(strings.GetPath("config.config_sources_title"))
 
-		customConfigFile := os.Getenv("GFL_CONFIG_FILE")
+		customConfigFile := utils.CustomConfigPath()
 		var customConfigLine string
 
 		for _, source := range configInfo.Sources {
@@ -198,6 +150,16 @@ var configCmd = &cobra.Command{
 				emoji = "🏠"
 			case strings.GetPath("config.custom_config"):
 				emoji = "🎯"
+			case strings.GetPath("config.user_config"):
+				emoji = "👤"
+			case strings.GetPath("config.directory_config"):
+				emoji = "📂"
+			case strings.GetPath("config.inherited_config"):
+				emoji = "🔗"
+			case strings.GetPath("config.git_repo_config"), strings.GetPath("config.git_global_config"):
+				emoji = "🔧"
+			case strings.GetPath("config.env_config"):
+				emoji = "🌱"
 			default:
 				emoji = "📄"
 			}
@@ -225,14 +187,270 @@ var configCmd = &cobra.Command{
 		}
 
 		// 3. 显示配置优先级说明
-		fmt.Printf(strings.GetPath("config.priority_title"))
-		fmt.Printf(strings.GetPath("config.priority_custom"))
-		fmt.Printf(strings.GetPath("config.priority_local"))
-		fmt.Printf(strings.GetPath("config.priority_global"))
-		fmt.Printf(strings.GetPath("config.priority_default"))
+		fmt.Print(strings.GetPath("config.priority_title"))
+		fmt.Print(strings.GetPath("config.priority_env"))
+		fmt.Print(strings.GetPath("config.priority_git"))
+		fmt.Print(strings.GetPath("config.priority_custom"))
+		fmt.Print(strings.GetPath("config.priority_local"))
+		fmt.Print(strings.GetPath("config.priority_global"))
+		fmt.Print(strings.GetPath("config.priority_user"))
+		fmt.Print(strings.GetPath("config.priority_default"))
+		fmt.Print(strings.GetPath("config.priority_directory"))
+		fmt.Print(strings.GetPath("config.priority_extends"))
+	},
+}
+
+var (
+	configGlobalFlag bool
+	configLocalFlag  bool
+	configUserFlag   bool
+	configFileFlag   bool
+)
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a config key", // Will be updated after strings load
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		key := args[0]
+		if _, _, err := utils.ConfigKeyType(key); err != nil {
+			utils.Error(err.Error())
+			return
+		}
+
+		// 指定了作用域时只读取该配置文件
+		if scope := configScope(""); scope != "" {
+			path, err := utils.ConfigScopePath(scope)
+			if err != nil {
+				utils.Error(err.Error())
+				return
+			}
+			value, ok := utils.GetConfigFileValue(path, key)
+			if !ok {
+				utils.Info(strings.GetPath("config.key_not_set", key, path))
+				return
+			}
+			fmt.Println(utils.FormatConfigValue(value))
+			return
+		}
+
+		configInfo := utils.ReadConfigWithSources()
+		value, _ := utils.LookupConfigValue(configInfo.Values(), key)
+		if value != nil {
+			fmt.Println(utils.FormatConfigValue(value))
+		}
+		// 来源输出到 stderr，方便脚本使用 $(gfl config get key)
+		fmt.Fprintln(os.Stderr, color.New(color.Faint).Sprint(describeConfigOrigin(configInfo, key)))
 	},
 }
 
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a config key in a config file", // Will be updated after strings load
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		key, value := args[0], args[1]
+		node, err := utils.ParseConfigValue(key, value)
+		if err != nil {
+			utils.Error(err.Error())
+			return
+		}
+
+		path, err := utils.ConfigScopePath(configScope(utils.ScopeLocal))
+		if err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		if err := utils.SetConfigFileValue(path, key, node); err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		utils.Success(strings.GetPath("config.set_success", key, value, path))
+		warnConfigOverridden(key, path)
+	},
+}
+
+// configUnsetCmd represents the config unset command
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a config key from a config file", // Will be updated after strings load
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		key := args[0]
+		if _, _, err := utils.ConfigKeyType(key); err != nil {
+			utils.Error(err.Error())
+			return
+		}
+
+		path, err := utils.ConfigScopePath(configScope(utils.ScopeLocal))
+		if err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		removed, err := utils.UnsetConfigFileValue(path, key)
+		if err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		if !removed {
+			utils.Info(strings.GetPath("config.key_not_set", key, path))
+			return
+		}
+		utils.Success(strings.GetPath("config.unset_success", key, path))
+		utils.Info(describeConfigOrigin(utils.ReadConfigWithSources(), key))
+	},
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List config keys as key=value", // Will be updated after strings load
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 指定了作用域时只列出该配置文件中的配置
+		if scope := configScope(""); scope != "" {
+			path, err := utils.ConfigScopePath(scope)
+			if err != nil {
+				utils.Error(err.Error())
+				return
+			}
+			for _, entry := range utils.FlattenConfigValues(utils.LoadConfigFile(path)) {
+				fmt.Println(entry)
+			}
+			return
+		}
+
+		configInfo := utils.ReadConfigWithSources()
+		for _, entry := range utils.FlattenConfigValues(configInfo.Values()) {
+			key := str.SplitN(entry, "=", 2)[0]
+			source := configInfo.SourceOf(key)
+			if source == "" {
+				source = strings.GetPath("config.default_value")
+			}
+			fmt.Printf("%s %s\n", entry, color.New(color.Faint).Sprintf("# %s", source))
+		}
+	},
+}
+
+var configSkipRemoteFlag bool
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check config files for unknown keys, invalid values and missing branches", // Will be updated after strings load
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		configInfo := utils.ReadConfigWithSources()
+
+		var issues []utils.ConfigIssue
+		for _, source := range configInfo.Sources {
+			issues = append(issues, utils.ValidateConfigFile(source.Path)...)
+		}
+
+		// 继承失败和循环继承在读取配置时发现，已在文件中报告过的不再重复
+		for _, issue := range utils.ExtendsIssues() {
+			if !containsIssue(issues, issue) {
+				issues = append(issues, issue)
+			}
+		}
+
+		// 检查配置中的分支在远程是否存在
+		if !configSkipRemoteFlag {
+			branchIssues, err := utils.ValidateConfigBranches(configInfo)
+			if err != nil {
+				utils.Warning(strings.GetPath("config.validate.remote_failed", err))
+			}
+			issues = append(issues, branchIssues...)
+		}
+
+		if len(issues) == 0 {
+			utils.Success(strings.GetPath("config.validate.valid"))
+			return
+		}
+		for _, issue := range issues {
+			fmt.Printf("  %s %s\n", color.RedString("✗"), issue.String())
+		}
+		utils.Error(strings.GetPath("config.validate.invalid", len(issues)))
+		// 以非零状态退出，便于在 CI 中使用
+		os.Exit(1)
+	},
+}
+
+// containsIssue reports whether the same problem was already found, the
+// message names the referenced file so the location is not compared
+func containsIssue(issues []utils.ConfigIssue, issue utils.ConfigIssue) bool {
+	for _, existing := range issues {
+		if existing.Key == issue.Key && existing.Message == issue.Message {
+			return true
+		}
+	}
+	return false
+}
+
+// configSchemaCmd represents the config schema command
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of .gfl.config.yml", // Will be updated after strings load
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := json.MarshalIndent(utils.ConfigJSONSchema(), "", "  ")
+		if err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		fmt.Println(string(data))
+	},
+}
+
+// configScope 返回命令行指定的配置作用域，未指定时返回 defaultScope
+func configScope(defaultScope string) string {
+	switch {
+	case configGlobalFlag:
+		return utils.ScopeGlobal
+	case configLocalFlag:
+		return utils.ScopeLocal
+	case configUserFlag:
+		return utils.ScopeUser
+	case configFileFlag:
+		return utils.ScopeFile
+	default:
+		return defaultScope
+	}
+}
+
+// describeConfigOrigin 描述配置项最终生效值的来源
+func describeConfigOrigin(configInfo utils.ConfigInfo, key string) string {
+	source, ok := configInfo.Origin(key)
+	if !ok {
+		return strings.GetPath("config.origin", strings.GetPath("config.default_value"), "-")
+	}
+	return strings.GetPath("config.origin", source.Name, source.Path)
+}
+
+// warnConfigOverridden 当写入的文件被更高优先级的配置覆盖时给出提示
+func warnConfigOverridden(key string, path string) {
+	source, ok := utils.ReadConfigWithSources().Origin(key)
+	if ok && source.Path != path {
+		utils.Warning(strings.GetPath("config.overridden", key, source.Name, source.Path))
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
+
+	for _, subCmd := range []*cobra.Command{configGetCmd, configSetCmd, configUnsetCmd, configListCmd} {
+		subCmd.Flags().BoolVar(&configGlobalFlag, "global", false, strings.GetPath("config.global_flag"))
+		subCmd.Flags().BoolVar(&configLocalFlag, "local", false, strings.GetPath("config.local_flag"))
+		subCmd.Flags().BoolVar(&configUserFlag, "user", false, strings.GetPath("config.user_flag"))
+		subCmd.Flags().BoolVar(&configFileFlag, "file", false, strings.GetPath("config.file_flag"))
+		subCmd.MarkFlagsMutuallyExclusive("global", "local", "user", "file")
+		configCmd.AddCommand(subCmd)
+	}
+
+	configValidateCmd.Flags().BoolVar(&configSkipRemoteFlag, "skip-remote", false, strings.GetPath("config.validate.skip_remote_flag"))
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
 }
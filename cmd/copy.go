@@ -26,10 +26,8 @@ var copyCmd = &cobra.Command{
 		// Determine the new branch name
 		var newBranchName string
 		if len(args) == 0 {
-			// No branch name provided, generate automatically
-			baseBranchName := generateCopyBranchName(config)
-			// Generate the full branch name with prefix and nickname
-			newBranchName = utils.GenerateBranchName(config, "feature", baseBranchName)
+			// No branch name provided, generate the full name automatically
+			newBranchName = generateCopyBranchName(config)
 
 			// Require -y flag if no branch name provided
 			if !copyConfirm {
@@ -42,9 +40,14 @@ var copyCmd = &cobra.Command{
 			newBranchName = args[0]
 		}
 
+		autostash, ok := getAutostashMode(cmd, config)
+		if !ok {
+			return
+		}
+
 		// Copy branch; skipGenerate is true when we already generated the full name
 		skipGenerate := (len(args) == 0)
-		copyBranch(config, newBranchName, skipGenerate)
+		copyBranch(config, newBranchName, skipGenerate, autostash)
 	},
 }
 
@@ -52,28 +55,38 @@ func init() {
 	rootCmd.AddCommand(copyCmd)
 	// Add --confirm flag for automatic name generation
 	copyCmd.Flags().BoolVarP(&copyConfirm, "confirm", "y", false, gflstrings.GetPath("copy.confirm_flag"))
+	addAutostashFlag(copyCmd)
 }
 
 // generateCopyBranchName generates a branch name by appending "-copyed" to the current branch name.
-// It extracts the base name from the current branch (removing prefix and nickname if present).
+// The current branch is split with the branch template, so the copy keeps its type and
+// issue while the prefix, nickname and date are regenerated for the new branch.
 func generateCopyBranchName(config *utils.YamlConfig) string {
 	currentBranch, err := utils.GetCurrentBranch()
 	if err != nil {
-		// If we can't get current branch, return a generic name
-		return "branch-copyed"
+		// If we can't get current branch, use a generic name
+		return utils.GenerateBranchName(config, "feature", "branch-copyed")
 	}
 
-	// Extract the last part of the branch name (the actual feature name)
-	parts := str.Split(currentBranch, "/")
-	baseName := parts[len(parts)-1]
+	parts, ok := utils.ParseBranchName(config, currentBranch)
+	if !ok {
+		// Not a gfl branch: use the last part of the branch name as feature name
+		segments := str.Split(currentBranch, "/")
+		return utils.GenerateBranchName(config, "feature", segments[len(segments)-1]+"-copyed")
+	}
 
 	// Append -copyed suffix
-	return baseName + "-copyed"
+	return utils.GenerateBranchNameFromParts(config, utils.BranchParts{
+		Type:  parts.Type,
+		Name:  parts.Name + "-copyed",
+		Issue: parts.Issue,
+	})
 }
 
 // copyBranch creates a new branch from the current branch.
 // It performs validation before creating the branch to ensure a safe copy operation.
-func copyBranch(config *utils.YamlConfig, newBranchName string, skipGenerate bool) {
+// With autostash enabled, uncommitted changes are stashed instead of refused.
+func copyBranch(config *utils.YamlConfig, newBranchName string, skipGenerate bool, autostash string) {
 	// Step 1: Generate branch name with proper prefix and case formatting
 	var generatedBranchName string
 	if skipGenerate {
@@ -84,8 +97,14 @@ func copyBranch(config *utils.YamlConfig, newBranchName string, skipGenerate boo
 		generatedBranchName = utils.GenerateBranchName(config, "feature", newBranchName)
 	}
 
+	// Clean up characters git does not allow and show the result
+	generatedBranchName, ok := sanitizeBranchName(config, generatedBranchName)
+	if !ok {
+		return
+	}
+
 	// Step 2: Check if working directory is clean
-	if !isWorkingDirectoryClean() {
+	if autostash == utils.AutostashOff && !isWorkingDirectoryClean() {
 		utils.Errorf(gflstrings.GetPath("copy.error.dirty"))
 		return
 	}
@@ -129,11 +148,26 @@ func copyBranch(config *utils.YamlConfig, newBranchName string, skipGenerate boo
 	// Step 7: Create new branch from current branch's remote version
 	remoteBranchRef := fmt.Sprintf("origin/%s", currentBranch)
 	checkoutCmd := fmt.Sprintf("git checkout -b %s %s", generatedBranchName, remoteBranchRef)
-	if err := utils.RunCommandWithSpin(checkoutCmd, gflstrings.GetPath("copy.copying")); err != nil {
+	copied := runWithAutostash(autostash, func() bool {
+		return utils.RunCommandWithSpin(checkoutCmd, gflstrings.GetPath("copy.copying")) == nil
+	})
+	if !copied {
 		return
 	}
 
-	// Step 8: Display success message
+	// Step 8: Record the stack parent. A copy shares the parent and fork point
+	// of its source; untracked sources become the parent themselves.
+	var recordErr error
+	if utils.GetBranchParent(currentBranch) != "" {
+		recordErr = utils.CopyBranchParent(currentBranch, generatedBranchName)
+	} else {
+		recordErr = utils.RecordBranchParent(generatedBranchName, currentBranch, remoteBranchRef)
+	}
+	if recordErr != nil {
+		utils.Warning(gflstrings.GetPath("stack.record_failed", recordErr))
+	}
+
+	// Step 9: Display success message
 	utils.Successf(gflstrings.GetPath("copy.success"), currentBranch, generatedBranchName)
 }
 
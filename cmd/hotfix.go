@@ -4,20 +4,25 @@ import (
 	"fmt"
 	"gfl/utils"
 	"gfl/utils/strings"
+	str "strings"
 
 	"github.com/spf13/cobra"
 )
 
 // hotfixCmd represents the hotfix command
 var hotfixCmd = &cobra.Command{
-	Use:     "hotfix [hotfix-name]",
+	Use:     "hotfix [hotfix-name...]",
 	Aliases: []string{"hf", "hot"},
 	Short:   "Start a hotfix branch", // Will be updated after strings load
-	Args:    cobra.ExactArgs(1),      // 要求提供一个参数
+	Args:    cobra.MinimumNArgs(1),   // 多个参数会以空格拼接为名称
 	Run: func(cmd *cobra.Command, args []string) {
 		config := utils.ReadConfig()
-		featureName := args[0] // 从参数中获取Hotfix名称
-		branchName := utils.GenerateBranchName(config, "hotfix", featureName)
+		featureName := str.Join(args, " ") // 从参数中获取Hotfix名称
+		hotfixType, _ := utils.GetBranchType(config, "hotfix")
+		branchName, ok := sanitizeBranchName(config, utils.GenerateBranchName(config, "hotfix", featureName))
+		if !ok {
+			return
+		}
 
 		//baseRemoteBranch := fmt.Sprintf("origin/%s", utils.GetLatestReleaseBranch())
 
@@ -28,7 +33,7 @@ var hotfixCmd = &cobra.Command{
 		}
 
 		// 执行命令: git checkout -b hotfix/aric/new-feature origin/develop
-		command2 := fmt.Sprintf("git checkout -b %s origin/%s", branchName, config.ProductionBranch)
+		command2 := fmt.Sprintf("git checkout -b %s origin/%s", branchName, hotfixType.Base)
 		utils.Infof(strings.GetPath("shell.executing_command"), command2)
 		if err := utils.RunCommandWithSpin(command2, strings.GetPath("hotfix.creating")); err != nil {
 			return
@@ -33,6 +33,12 @@ func displayInfo() {
 	// Build info lines for display
 	lines := buildInfoLines(info)
 
+	// Split the current branch into its parts using the branch template
+	config := utils.ReadConfig()
+	if parts, ok := utils.ParseBranchName(config, info.CurrentBranch); ok {
+		lines = append(lines[:1], append(buildBranchPartLines(parts), lines[1:]...)...)
+	}
+
 	// Display using ASCII box
 	box.PrintASCIIBox(lines)
 	fmt.Println()
@@ -118,3 +124,31 @@ func buildInfoLines(info *utils.BranchInfo) []string {
 
 	return lines
 }
+
+func buildBranchPartLines(parts *utils.BranchParts) []string {
+	lines := []string{}
+
+	if parts.Type != "" {
+		lines = append(lines, fmt.Sprintf("   🔖 %s: %s",
+			strings.GetPath("info.branch_type"),
+			parts.Type))
+	}
+
+	if parts.Nickname != "" {
+		lines = append(lines, fmt.Sprintf("   🙋 %s: %s",
+			strings.GetPath("info.branch_owner"),
+			parts.Nickname))
+	}
+
+	if parts.Issue != "" {
+		lines = append(lines, fmt.Sprintf("   🎫 %s: %s",
+			strings.GetPath("info.branch_issue"),
+			parts.Issue))
+	}
+
+	lines = append(lines, fmt.Sprintf("   📝 %s: %s",
+		strings.GetPath("info.branch_name"),
+		parts.Name))
+
+	return lines
+}
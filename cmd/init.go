@@ -3,6 +3,11 @@ package cmd
 import (
 	"embed"
 	"gfl/utils"
+	"gfl/utils/strings"
+	"os"
+	str "strings"
+
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -19,6 +24,12 @@ var initCmd = &cobra.Command{
 		// get flag
 		force, _ := cmd.Flags().GetBool("force")
 		nickname, _ := cmd.Flags().GetString("nickname")
+		interactive, _ := cmd.Flags().GetBool("interactive")
+
+		if interactive {
+			runInitWizard(force, nickname)
+			return
+		}
 
 		gflConfig, _ := assets.ReadFile("assets/.gfl.config.yml")
 		gflLocalConfig, _ := assets.ReadFile("assets/.gfl.config.local.yml")
@@ -69,4 +80,220 @@ func init() {
 	initCmd.Flags().BoolP("force", "f", false, "Force overwrite existing configuration file") // Will be updated after strings load
 	// 添加 --nickname 标志
 	initCmd.Flags().StringP("nickname", "n", "", "Set Github Flow nickname (optional)") // Will be updated after strings load
+	// 添加 --interactive 标志
+	initCmd.Flags().BoolP("interactive", "i", false, "Answer questions about the repository to generate the configuration") // Will be updated after strings load
+}
+
+// runInitWizard 交互式初始化：根据仓库已有的分支和 git 用户信息给出建议，确认后写入配置
+func runInitWizard(force bool, nickname string) {
+	// 已有配置且未指定 --force 时不提问，直接提示（配置文件总是在仓库根目录）
+	for _, filename := range []string{utils.RepoConfigPath(utils.GlobalConfigFile), utils.RepoConfigPath(utils.LocalConfigFile)} {
+		if _, err := os.Stat(filename); err == nil && !force {
+			utils.Errorf(strings.GetPath("init.config_exists_error"), filename)
+			return
+		}
+	}
+
+	branches := initExistingBranches()
+	defaultBranch, err := utils.GetRemoteDefaultBranch()
+	if err == nil {
+		utils.Info(strings.GetPath("init.wizard.remote_default", defaultBranch))
+	}
+
+	// 1. 生产分支：远程默认分支优先
+	productionBranch, err := askInitBranch(
+		strings.GetPath("init.wizard.production_branch"),
+		initBranchOptions(branches, defaultBranch, "main", "master"),
+		"main",
+	)
+	if err != nil {
+		utils.Error(strings.GetPath("init.wizard.cancelled"))
+		return
+	}
+
+	// 2. 开发基础分支：develop/dev 优先，没有时使用生产分支
+	devBaseBranch, err := askInitBranch(
+		strings.GetPath("init.wizard.dev_base_branch"),
+		initBranchOptions(branches, "develop", "dev", productionBranch),
+		"dev",
+	)
+	if err != nil {
+		utils.Error(strings.GetPath("init.wizard.cancelled"))
+		return
+	}
+
+	// 3. 昵称：默认取 git config user.name 的第一个单词
+	if nickname == "" {
+		nickname = utils.SuggestNickname()
+	}
+	if err := survey.AskOne(&survey.Input{
+		Message: strings.GetPath("init.wizard.nickname"),
+		Default: nickname,
+	}, &nickname); err != nil {
+		utils.Error(strings.GetPath("init.wizard.cancelled"))
+		return
+	}
+
+	// 4. 分支名称格式：根据已有分支推测
+	config := utils.ReadConfig()
+	config.ProductionBranch = productionBranch
+	config.DevBaseBranch = devBaseBranch
+	config.Nickname = nickname
+
+	caseField, _ := utils.LookupConfigField("branchCaseFormat")
+	caseFormat := utils.DetectBranchCaseFormat(branches)
+	if err := survey.AskOne(&survey.Select{
+		Message: strings.GetPath("init.wizard.case_format"),
+		Options: caseField.Enum,
+		Default: caseFormat,
+		Description: func(value string, index int) string {
+			preview := *config
+			preview.BranchCaseFormat = value
+			return utils.GenerateBranchName(&preview, "feature", "user login")
+		},
+	}, &caseFormat); err != nil {
+		utils.Error(strings.GetPath("init.wizard.cancelled"))
+		return
+	}
+	config.BranchCaseFormat = caseFormat
+
+	// 5. 预览并确认
+	utils.Info(strings.GetPath("init.wizard.preview", utils.GenerateBranchName(config, "feature", "user login"), devBaseBranch))
+	confirmed := true
+	if err := survey.AskOne(&survey.Confirm{
+		Message: strings.GetPath("init.wizard.confirm"),
+		Default: true,
+	}, &confirmed); err != nil || !confirmed {
+		utils.Error(strings.GetPath("init.wizard.cancelled"))
+		return
+	}
+
+	if err := writeInitWizardConfig(force, config); err != nil {
+		utils.Error(err.Error())
+		return
+	}
+	utils.Success(strings.GetPath("init.wizard.done", utils.RepoConfigPath(utils.GlobalConfigFile), utils.RepoConfigPath(utils.LocalConfigFile)))
+}
+
+// writeInitWizardConfig 写入向导的结果：团队设置写入全局配置（保留模板注释），昵称写入本地配置。
+// 在子目录中运行时也写入仓库根目录，否则会变成子目录覆盖配置
+func writeInitWizardConfig(force bool, config *utils.YamlConfig) error {
+	globalConfigFile := utils.RepoConfigPath(utils.GlobalConfigFile)
+	localConfigFile := utils.RepoConfigPath(utils.LocalConfigFile)
+
+	gflConfig, _ := assets.ReadFile("assets/.gfl.config.yml")
+	if err := utils.CreateGflConfigFromBytes(gflConfig, utils.CreateGflConfigOptions{
+		Filename: globalConfigFile,
+		Force:    force,
+	}); err != nil {
+		return err
+	}
+
+	teamValues := map[string]string{
+		"devBaseBranch":    config.DevBaseBranch,
+		"productionBranch": config.ProductionBranch,
+		"branchCaseFormat": config.BranchCaseFormat,
+	}
+	for _, key := range []string{"devBaseBranch", "productionBranch", "branchCaseFormat"} {
+		node, err := utils.ParseConfigValue(key, teamValues[key])
+		if err != nil {
+			return err
+		}
+		if err := utils.SetConfigFileValue(globalConfigFile, key, node); err != nil {
+			return err
+		}
+	}
+	// 昵称是个人设置，不写入团队共享的全局配置
+	if _, err := utils.UnsetConfigFileValue(globalConfigFile, "nickname"); err != nil {
+		return err
+	}
+
+	if force {
+		_ = os.Remove(localConfigFile)
+	}
+	if config.Nickname != "" {
+		node, err := utils.ParseConfigValue("nickname", config.Nickname)
+		if err != nil {
+			return err
+		}
+		if err := utils.SetConfigFileValue(localConfigFile, "nickname", node); err != nil {
+			return err
+		}
+		utils.AddGitIgnore()
+	}
+	return nil
+}
+
+// askInitBranch 从已有分支中选择，也可以手动输入；没有候选分支时直接输入
+func askInitBranch(message string, options []string, fallback string) (string, error) {
+	var answer string
+	if len(options) > 0 {
+		other := strings.GetPath("init.wizard.other_branch")
+		err := survey.AskOne(&survey.Select{
+			Message: message,
+			Options: append(options, other),
+			Default: options[0],
+		}, &answer)
+		if err != nil {
+			return "", err
+		}
+		if answer != other {
+			return answer, nil
+		}
+		fallback = options[0]
+	}
+
+	err := survey.AskOne(&survey.Input{
+		Message: message,
+		Default: fallback,
+	}, &answer, survey.WithValidator(survey.Required))
+	return str.TrimSpace(answer), err
+}
+
+// initExistingBranches 返回本地和远程分支名（去掉 origin/ 前缀并去重）
+func initExistingBranches() []string {
+	seen := map[string]bool{}
+	var branches []string
+	add := func(name string) {
+		if name != "" && !seen[name] && !str.HasSuffix(name, "/HEAD") && name != "HEAD" {
+			seen[name] = true
+			branches = append(branches, name)
+		}
+	}
+
+	localBranches, _ := utils.GetLocalBranchNames()
+	for _, branch := range localBranches {
+		add(branch)
+	}
+	remoteBranches, _ := utils.GetRemoteBranches()
+	for _, branch := range remoteBranches {
+		// git branch -r 会输出 "origin/HEAD -> origin/main"
+		branch = str.TrimSpace(str.Split(branch, " -> ")[0])
+		add(str.TrimPrefix(branch, "origin/"))
+	}
+	return branches
+}
+
+// initBranchOptions 按优先级返回仓库中存在的候选分支
+func initBranchOptions(branches []string, candidates ...string) []string {
+	var options []string
+	for _, candidate := range candidates {
+		if candidate == "" || containsBranch(options, candidate) {
+			continue
+		}
+		if containsBranch(branches, candidate) {
+			options = append(options, candidate)
+		}
+	}
+	return options
+}
+
+// containsBranch 判断分支是否在列表中
+func containsBranch(branches []string, branch string) bool {
+	for _, b := range branches {
+		if b == branch {
+			return true
+		}
+	}
+	return false
 }
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"gfl/utils"
 	"gfl/utils/strings"
+	"os"
 
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
 )
@@ -52,21 +56,158 @@ var prCmd = &cobra.Command{
 			return
 		}
 
-		// 确定目标分支
+		// 确定目标分支：参数 > 记录的父分支 > 配置的开发分支
 		var baseBranch = config.DevBaseBranch
 		if len(args) > 0 {
 			baseBranch = args[0]
+		} else if parent := utils.GetBranchParent(currentBranch); parent != "" {
+			if exists, err := utils.RemoteBranchExists(parent); err == nil && exists {
+				baseBranch = parent
+			}
 		}
 
 		// 创建 GitHub PR
-		utils.CreatePr(baseBranch, currentBranch)
+		utils.CreatePr(config, baseBranch, currentBranch)
 	},
 }
 
+// prStatusCmd represents the pr status command
+var prStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show PRs and checks for local branches", // Will be updated after strings load
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := utils.ReadConfig()
+		if config == nil {
+			return
+		}
+
+		if !utils.IsCommandAvailable("gh") {
+			utils.Error(strings.GetPath("pr.gh_required"))
+			return
+		}
+
+		currentBranch, err := utils.GetCurrentBranch()
+		if err != nil {
+			utils.Errorf(strings.GetPath("pr.current_branch_error", err))
+			return
+		}
+
+		// 收集当前分支和所有带有自己昵称的本地分支
+		localBranches, err := utils.GetLocalBranchNames()
+		if err != nil {
+			utils.Errorf(strings.GetPath("pr.status_error", err))
+			return
+		}
+
+		branches := []string{currentBranch}
+		for _, branch := range localBranches {
+			if branch != currentBranch && utils.BranchHasNickname(branch, config.Nickname) {
+				branches = append(branches, branch)
+			}
+		}
+
+		// 单个分支查询失败（如触发限流）不影响其他分支，错误记录在对应的条目中
+		results := utils.GetBranchPullRequests(branches, currentBranch)
+
+		// JSON 输出，方便脚本使用
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			data, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				utils.Errorf(strings.GetPath("pr.status_error", err))
+				return
+			}
+			fmt.Println(string(data))
+			return
+		}
+
+		renderPrStatusTable(results)
+		for _, result := range results {
+			if result.Error != "" {
+				utils.Warning(strings.GetPath("pr.branch_status_error", result.Error))
+			}
+		}
+	},
+}
+
+// renderPrStatusTable 以表格形式显示分支的 PR 状态
+func renderPrStatusTable(results []utils.BranchPullRequest) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetTitle(strings.GetPath("pr.status_title"))
+	t.SetStyle(table.StyleRounded)
+
+	t.AppendHeader(table.Row{
+		color.New(color.FgCyan, color.Bold).Sprint(strings.GetPath("pr.column_branch")),
+		color.New(color.FgCyan, color.Bold).Sprint(strings.GetPath("pr.column_number")),
+		color.New(color.FgCyan, color.Bold).Sprint(strings.GetPath("pr.column_state")),
+		color.New(color.FgCyan, color.Bold).Sprint(strings.GetPath("pr.column_review")),
+		color.New(color.FgCyan, color.Bold).Sprint(strings.GetPath("pr.column_checks")),
+		color.New(color.FgCyan, color.Bold).Sprint(strings.GetPath("pr.column_mergeable")),
+	})
+
+	for _, result := range results {
+		branch := result.Branch
+		if result.Current {
+			branch = color.New(color.FgGreen, color.Bold).Sprint("* " + branch)
+		}
+
+		if result.Error != "" {
+			t.AppendRow(table.Row{branch, "-", color.RedString(strings.GetPath("pr.lookup_failed")), "-", "-", "-"})
+			continue
+		}
+
+		pr := result.PullRequest
+		if pr == nil {
+			t.AppendRow(table.Row{branch, "-", strings.GetPath("pr.no_pr"), "-", "-", "-"})
+			continue
+		}
+
+		state := pr.State
+		if pr.IsDraft && state == "OPEN" {
+			state = "DRAFT"
+		}
+
+		t.AppendRow(table.Row{
+			branch,
+			fmt.Sprintf("#%d", pr.Number),
+			colorizePrValue(state),
+			colorizePrValue(pr.ReviewDecision),
+			colorizePrValue(pr.Checks),
+			colorizePrValue(pr.Mergeable),
+		})
+	}
+
+	t.Render()
+}
+
+// colorizePrValue 根据 PR 状态值添加颜色
+func colorizePrValue(value string) string {
+	switch value {
+	case "":
+		return "-"
+	case "OPEN", "APPROVED", "SUCCESS", "MERGEABLE":
+		return color.GreenString(value)
+	case "MERGED":
+		return color.MagentaString(value)
+	case "CLOSED", "CHANGES_REQUESTED", "FAILURE", "CONFLICTING":
+		return color.RedString(value)
+	case "DRAFT", "REVIEW_REQUIRED", "PENDING":
+		return color.YellowString(value)
+	default:
+		return value
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(prCmd)
 
 	// 添加命令标志
 	prCmd.Flags().BoolP("sync", "s", false, strings.GetPath("pr.sync_flag"))
 	prCmd.Flags().BoolP("open", "o", false, strings.GetPath("pr.open_flag"))
+
+	// 添加 status 子命令
+	prCmd.AddCommand(prStatusCmd)
+	prStatusCmd.Flags().Bool("json", false, strings.GetPath("pr.json_flag"))
 }
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"gfl/utils"
+	"gfl/utils/strings"
+
+	"github.com/spf13/cobra"
+)
+
+// forceProtectedFlag 允许命令操作受保护的分支（protectedBranches）
+var forceProtectedFlag bool
+
+// addForceProtectedFlag 为会删除、重命名、推送或 rebase 分支的命令添加 --force-protected
+func addForceProtectedFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&forceProtectedFlag, "force-protected", false, strings.GetPath("protected.force_flag"))
+}
+
+// checkProtectedBranch 检查分支是否受保护，受保护且未指定 --force-protected 时提示并返回 false
+func checkProtectedBranch(config *utils.YamlConfig, branch string) bool {
+	pattern, protected := utils.IsProtectedBranch(config, branch)
+	if !protected {
+		return true
+	}
+	if forceProtectedFlag {
+		utils.Warning(strings.GetPath("protected.forced", branch, pattern))
+		return true
+	}
+	utils.Error(strings.GetPath("protected.refused", branch, pattern))
+	return false
+}
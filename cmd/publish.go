@@ -11,6 +11,16 @@ var publishCmd = &cobra.Command{
 	Aliases: []string{"p"},
 	Short:   "Publish current branch (alias: p)", // Will be updated after strings load
 	Run: func(cmd *cobra.Command, args []string) {
+		// 受保护的分支（如 main、dev）不允许直接推送
+		currentBranch, err := utils.GetCurrentBranch()
+		if err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		if !checkProtectedBranch(utils.ReadConfig(), currentBranch) {
+			return
+		}
+
 		// 执行命令: git push -u origin HEAD
 		if err := utils.RunCommandWithSpin("git push -u origin HEAD", strings.GetPath("publish.pushing")); err != nil {
 			return
@@ -20,5 +30,6 @@ var publishCmd = &cobra.Command{
 }
 
 func init() {
+	addForceProtectedFlag(publishCmd)
 	rootCmd.AddCommand(publishCmd)
 }
@@ -19,6 +19,10 @@ var rebaseCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		// Read configuration
 		config := utils.ReadConfig()
+		autostash, ok := getAutostashMode(cmd, config)
+		if !ok {
+			return
+		}
 
 		// Get current branch
 		currentBranch, err := utils.GetCurrentBranch()
@@ -27,6 +31,11 @@ var rebaseCmd = &cobra.Command{
 			return
 		}
 
+		// Protected branches (e.g., main, dev) are never rebased
+		if config != nil && !checkProtectedBranch(config, currentBranch) {
+			return
+		}
+
 		// Use configured dev base branch or default
 		devBranch := "dev"
 		if config != nil && config.DevBaseBranch != "" {
@@ -43,8 +52,14 @@ var rebaseCmd = &cobra.Command{
 
 		// Perform rebase
 		rebaseCmd := fmt.Sprintf("git rebase origin/%s", devBranch)
-		if err := utils.RunCommandWithSpin(rebaseCmd, fmt.Sprintf(strings.GetPath("rebase.rebasing", devBranch))); err != nil {
-			utils.Errorf(strings.GetPath("rebase.rebase_failed", err))
+		rebased := runWithAutostash(autostash, func() bool {
+			if err := utils.RunCommandWithSpin(rebaseCmd, fmt.Sprintf(strings.GetPath("rebase.rebasing", devBranch))); err != nil {
+				utils.Errorf(strings.GetPath("rebase.rebase_failed", err))
+				return false
+			}
+			return true
+		})
+		if !rebased {
 			return
 		}
 
@@ -54,6 +69,8 @@ var rebaseCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(rebaseCmd)
+	addForceProtectedFlag(rebaseCmd)
+	addAutostashFlag(rebaseCmd)
 
 	// Update command description after strings are loaded
 	rebaseCmd.Short = strings.GetPath("rebase.short")
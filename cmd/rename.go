@@ -19,8 +19,20 @@ var renameCmd = &cobra.Command{
 	Short:   "Rename a branch (local and/or remote)", // Will be updated after strings load
 	Args:    cobra.ExactArgs(2), // 需要两个参数：旧分支名和新分支名
 	Run: func(cmd *cobra.Command, args []string) {
+		config := utils.ReadConfig()
+		if config == nil {
+			return
+		}
+
 		oldBranch := args[0]
-		newBranch := args[1]
+		// 受保护的分支（如 main、dev）不允许重命名
+		if !checkProtectedBranch(config, oldBranch) {
+			return
+		}
+		newBranch, ok := sanitizeBranchName(config, args[1])
+		if !ok {
+			return
+		}
 		// get flag confirm
 		confirm, _ := cmd.Flags().GetBool("confirm")
 
@@ -32,7 +44,7 @@ var renameCmd = &cobra.Command{
 		if renameLocalFlag {
 			// 重命名本地分支
 			if err := utils.RenameLocalBranch(oldBranch, newBranch, confirm); err != nil {
-				utils.Errorf(err.Error())
+				utils.Error(err.Error())
 				return
 			}
 		}
@@ -40,7 +52,7 @@ var renameCmd = &cobra.Command{
 		if renameRemoteFlag {
 			// 处理远程分支
 			if err := utils.HandleRemoteBranch(oldBranch, newBranch, renameDeleteFlag, confirm); err != nil {
-				utils.Errorf(err.Error())
+				utils.Error(err.Error())
 				return
 			}
 		}
@@ -55,5 +67,6 @@ func init() {
 	renameCmd.Flags().BoolVarP(&renameLocalFlag, "local", "l", false, strings.GetPath("rename.local_flag"))
 	renameCmd.Flags().BoolVarP(&renameRemoteFlag, "remote", "r", false, strings.GetPath("rename.remote_flag"))
 	renameCmd.Flags().BoolVarP(&renameDeleteFlag, "delete", "d", false, strings.GetPath("rename.delete_flag"))
+	addForceProtectedFlag(renameCmd)
 	rootCmd.AddCommand(renameCmd)
 }
@@ -16,6 +16,11 @@ var restoreCmd = &cobra.Command{
 		// get flag confirm
 		confirm, _ := cmd.Flags().GetBool("confirm")
 
+		// 受保护的分支（如 main、dev）上不丢弃修改
+		if currentBranch, err := utils.GetCurrentBranch(); err == nil && !checkProtectedBranch(utils.ReadConfig(), currentBranch) {
+			return
+		}
+
 		if len(args) == 0 {
 			// 没有参数时，作用于当前目录
 			utils.RestorePath(".", confirm)
@@ -33,5 +38,6 @@ var restoreCmd = &cobra.Command{
 }
 
 func init() {
+	addForceProtectedFlag(restoreCmd)
 	rootCmd.AddCommand(restoreCmd)
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"gfl/utils"
+	"gfl/utils/lang"
 	"gfl/utils/strings"
 	"os"
 
@@ -28,6 +29,7 @@ var rootCmd = &cobra.Command{
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		// Apply debug flag override before command execution
 		utils.SetDebugOverride(debugFlagValue)
+		applyConfiguredLanguage()
 	},
 }
 
@@ -52,7 +54,20 @@ func Execute() {
 func init() {
 	// Cobra will automatically add --version/-v flag when Version field is set
 	rootCmd.PersistentFlags().BoolP("confirm", "y", false, "Confirm operation") // Will be updated after strings load
-	rootCmd.PersistentFlags().BoolVarP(&debugFlagValue, "debug", "d", false, "Enable debug mode") // Will be updated after strings load
+	// No shorthand: a global -d clashes with 'rename --delete' (-d) and cobra panics
+	// with "unable to redefine 'd' shorthand"
+	rootCmd.PersistentFlags().BoolVar(&debugFlagValue, "debug", false, "Enable debug mode") // Will be updated after strings load
+}
+
+// applyConfiguredLanguage 应用配置中的语言设置（如用户配置），优先级低于 GFL_LANG
+// 只读取 language 一项且不报错，--help 不经过这里，也不会触发 git 或配置错误
+func applyConfiguredLanguage() {
+	if os.Getenv("GFL_LANG") != "" {
+		return
+	}
+	if language := utils.ConfiguredLanguage(); language != "" {
+		strings.SetLanguage(lang.Language(language))
+	}
 }
 
 // updateCommandDescriptions updates all command descriptions after strings are loaded
@@ -64,6 +79,18 @@ func updateCommandDescriptions() {
 	rootCmd.PersistentFlags().Lookup("confirm").Usage = strings.GetPath("root.confirm_flag")
 	rootCmd.PersistentFlags().Lookup("debug").Usage = strings.GetPath("root.debug_flag")
 
+	// Update --force-protected on the commands that accept it
+	for _, cmd := range []*cobra.Command{sweepCmd, renameCmd, publishCmd, restoreCmd, rebaseCmd, stackRestackCmd, syncCmd} {
+		if flag := cmd.Flags().Lookup("force-protected"); flag != nil {
+			flag.Usage = strings.GetPath("protected.force_flag")
+		}
+	}
+
+	// Update --autostash on the commands that switch branches or rebase
+	for _, cmd := range []*cobra.Command{checkoutCmd, startCmd, copyCmd, rebaseCmd} {
+		cmd.Flags().Lookup("autostash").Usage = strings.GetPath("autostash.flag")
+	}
+
 	// Update start command
 	if startCmd != nil {
 		startCmd.Short = strings.GetPath("start.short")
@@ -74,6 +101,7 @@ func updateCommandDescriptions() {
 		initCmd.Short = strings.GetPath("init.short")
 		initCmd.Flags().Lookup("force").Usage = strings.GetPath("init.force_flag")
 		initCmd.Flags().Lookup("nickname").Usage = strings.GetPath("init.nickname_flag")
+		initCmd.Flags().Lookup("interactive").Usage = strings.GetPath("init.interactive_flag")
 	}
 
 	// Update publish command
@@ -94,6 +122,7 @@ func updateCommandDescriptions() {
 	// Update sync command
 	if syncCmd != nil {
 		syncCmd.Short = strings.GetPath("sync.short")
+		syncCmd.Flags().Lookup("prune-local").Usage = strings.GetPath("sync.prune_local_flag")
 	}
 
 	// Update tag command
@@ -109,6 +138,12 @@ func updateCommandDescriptions() {
 		prCmd.Flags().Lookup("open").Usage = strings.GetPath("pr.open_flag")
 	}
 
+	// Update pr status command
+	if prStatusCmd != nil {
+		prStatusCmd.Short = strings.GetPath("pr.status_short")
+		prStatusCmd.Flags().Lookup("json").Usage = strings.GetPath("pr.json_flag")
+	}
+
 	// Update sweep command
 	if sweepCmd != nil {
 		sweepCmd.Short = strings.GetPath("sweep.short")
@@ -116,6 +151,14 @@ func updateCommandDescriptions() {
 		sweepCmd.Flags().Lookup("remote").Usage = strings.GetPath("sweep.remote_flag")
 		sweepCmd.Flags().Lookup("exact").Usage = strings.GetPath("sweep.exact_flag")
 		sweepCmd.Flags().Lookup("force").Usage = strings.GetPath("sweep.force_flag")
+		sweepCmd.Flags().Lookup("mine").Usage = strings.GetPath("sweep.mine_flag")
+		sweepCmd.Flags().Lookup("merged").Usage = strings.GetPath("sweep.merged_flag")
+		sweepCmd.Flags().Lookup("stale").Usage = strings.GetPath("sweep.stale_flag")
+		sweepCmd.Flags().Lookup("author").Usage = strings.GetPath("sweep.author_flag")
+		sweepCmd.Flags().Lookup("interactive").Usage = strings.GetPath("sweep.interactive_flag")
+		sweepCmd.Flags().Lookup("glob").Usage = strings.GetPath("sweep.glob_flag")
+		sweepCmd.Flags().Lookup("regex").Usage = strings.GetPath("sweep.regex_flag")
+		sweepCmd.Flags().Lookup("exclude").Usage = strings.GetPath("sweep.exclude_flag")
 	}
 
 	// Update release command
@@ -130,6 +173,31 @@ func updateCommandDescriptions() {
 		configCmd.Short = strings.GetPath("config.short")
 		configCmd.Long = strings.GetPath("config.long")
 	}
+	if configGetCmd != nil {
+		configGetCmd.Short = strings.GetPath("config.get_short")
+	}
+	if configSetCmd != nil {
+		configSetCmd.Short = strings.GetPath("config.set_short")
+	}
+	if configUnsetCmd != nil {
+		configUnsetCmd.Short = strings.GetPath("config.unset_short")
+	}
+	if configListCmd != nil {
+		configListCmd.Short = strings.GetPath("config.list_short")
+	}
+	if configValidateCmd != nil {
+		configValidateCmd.Short = strings.GetPath("config.validate.short")
+		configValidateCmd.Flags().Lookup("skip-remote").Usage = strings.GetPath("config.validate.skip_remote_flag")
+	}
+	if configSchemaCmd != nil {
+		configSchemaCmd.Short = strings.GetPath("config.schema_short")
+	}
+	for _, subCmd := range []*cobra.Command{configGetCmd, configSetCmd, configUnsetCmd, configListCmd} {
+		subCmd.Flags().Lookup("global").Usage = strings.GetPath("config.global_flag")
+		subCmd.Flags().Lookup("local").Usage = strings.GetPath("config.local_flag")
+		subCmd.Flags().Lookup("user").Usage = strings.GetPath("config.user_flag")
+		subCmd.Flags().Lookup("file").Usage = strings.GetPath("config.file_flag")
+	}
 
 	if rebaseCmd != nil {
 		rebaseCmd.Short = strings.GetPath("rebase.short")
@@ -159,6 +227,31 @@ func updateCommandDescriptions() {
 		infoCmd.Short = strings.GetPath("info.short")
 	}
 
+	// Update branches command
+	if branchesCmd != nil {
+		branchesCmd.Short = strings.GetPath("branches.short")
+		branchesCmd.Flags().Lookup("mine").Usage = strings.GetPath("branches.mine_flag")
+		branchesCmd.Flags().Lookup("remote").Usage = strings.GetPath("branches.remote_flag")
+		branchesCmd.Flags().Lookup("type").Usage = strings.GetPath("branches.type_flag")
+		branchesCmd.Flags().Lookup("json").Usage = strings.GetPath("branches.json_flag")
+	}
+
+	// Update trash command
+	if trashCmd != nil {
+		trashCmd.Short = strings.GetPath("trash.short")
+		trashListCmd.Short = strings.GetPath("trash.list_short")
+		trashRestoreCmd.Short = strings.GetPath("trash.restore_short")
+		trashPurgeCmd.Short = strings.GetPath("trash.purge_short")
+		trashRestoreCmd.Flags().Lookup("push").Usage = strings.GetPath("trash.push_flag")
+		trashPurgeCmd.Flags().Lookup("older-than").Usage = strings.GetPath("trash.older_than_flag")
+	}
+
+	// Update stack command
+	if stackCmd != nil {
+		stackCmd.Short = strings.GetPath("stack.short")
+		stackRestackCmd.Short = strings.GetPath("stack.restack_short")
+	}
+
 	// Update copy command
 	if copyCmd != nil {
 		copyCmd.Short = strings.GetPath("copy.short")
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"gfl/utils"
+	"gfl/utils/strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// stackCmd represents the stack command
+var stackCmd = &cobra.Command{
+	Use:     "stack",
+	Aliases: []string{"st"},
+	Short:   "Show stacked branches (alias: st)", // Will be updated after strings load
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := utils.ReadConfig()
+		if config == nil {
+			return
+		}
+
+		parents := utils.GetStackParents()
+		if len(parents) == 0 {
+			utils.Info(strings.GetPath("stack.empty"))
+			return
+		}
+
+		currentBranch, _ := utils.GetCurrentBranch()
+		for _, root := range utils.BuildStackTree(parents) {
+			fmt.Println(color.New(color.FgCyan, color.Bold).Sprint(root.Name))
+			printStackChildren(config, root, "", currentBranch)
+		}
+	},
+}
+
+// stackRestackCmd represents the stack restack command
+var stackRestackCmd = &cobra.Command{
+	Use:     "restack",
+	Aliases: []string{"rs"},
+	Short:   "Rebase every stacked branch onto its parent", // Will be updated after strings load
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := utils.ReadConfig()
+		if config == nil {
+			return
+		}
+
+		if !isWorkingDirectoryClean() {
+			utils.Error(strings.GetPath("stack.dirty"))
+			return
+		}
+
+		currentBranch, err := utils.GetCurrentBranch()
+		if err != nil {
+			utils.Errorf(strings.GetPath("stack.current_branch_error", err))
+			return
+		}
+
+		// 执行命令: git fetch origin（确保父分支是最新的）
+		if err := utils.RunCommandWithSpin("git fetch origin", strings.GetPath("stack.syncing")); err != nil {
+			return
+		}
+
+		parents := utils.GetStackParents()
+		if len(parents) == 0 {
+			utils.Info(strings.GetPath("stack.empty"))
+			return
+		}
+
+		// 父分支已合并时，将子分支挂到最近的未合并祖先上
+		reparented := map[string]string{}
+		for branch, parent := range parents {
+			newParent := utils.ResolveStackParent(config, parents, branch)
+			if newParent != parent {
+				utils.Info(strings.GetPath("stack.reparenting", branch, parent, newParent))
+				reparented[branch] = newParent
+			}
+		}
+		for branch, newParent := range reparented {
+			parents[branch] = newParent
+		}
+
+		// 按照从根到叶的顺序 rebase，确保父分支先于子分支完成
+		restacked := 0
+		queue := utils.BuildStackTree(parents)
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			queue = append(queue, node.Children...)
+
+			if node.Parent == "" {
+				continue
+			}
+			if _, moved := reparented[node.Name]; !moved && !utils.NeedsRestack(config, *node) {
+				continue
+			}
+
+			// 受保护的分支（如 dev）不 rebase
+			if !checkProtectedBranch(config, node.Name) {
+				continue
+			}
+
+			if err := utils.RestackBranch(config, node.Name, node.Parent); err != nil {
+				utils.Errorf(strings.GetPath("stack.restack_failed", node.Name, err))
+				utils.Info(strings.GetPath("stack.resolve_hint"))
+				return
+			}
+			utils.Successf(strings.GetPath("stack.restack_success", node.Name, node.Parent))
+			restacked++
+		}
+
+		// 恢复到原来的分支
+		if err := utils.RunCommandWithSpin(fmt.Sprintf("git checkout %s", currentBranch), strings.GetPath("stack.returning")); err != nil {
+			utils.Errorf(strings.GetPath("stack.return_failed", currentBranch, err))
+			return
+		}
+
+		if restacked == 0 {
+			utils.Success(strings.GetPath("stack.up_to_date"))
+		}
+	},
+}
+
+// printStackChildren 以树形结构递归打印子分支
+func printStackChildren(config *utils.YamlConfig, node *utils.StackBranch, indent string, currentBranch string) {
+	for i, child := range node.Children {
+		connector, childIndent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			connector, childIndent = "└── ", "    "
+		}
+
+		name := child.Name
+		if child.Name == currentBranch {
+			name = color.New(color.FgGreen, color.Bold).Sprint("* " + name)
+		}
+
+		status := ""
+		if utils.IsStackParentMerged(config, child.Parent) {
+			status = color.YellowString(" (%s)", strings.GetPath("stack.parent_merged"))
+		} else if utils.NeedsRestack(config, *child) {
+			status = color.YellowString(" (%s)", strings.GetPath("stack.needs_restack"))
+		}
+
+		fmt.Printf("%s%s%s%s\n", indent, connector, name, status)
+		printStackChildren(config, child, indent+childIndent, currentBranch)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(stackCmd)
+	stackCmd.AddCommand(stackRestackCmd)
+	addForceProtectedFlag(stackRestackCmd)
+}
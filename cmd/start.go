@@ -12,24 +12,40 @@ import (
 var startBaseBranch string // 存储 --base 参数值
 
 var startCmd = &cobra.Command{
-	Use:     "start [feature-name]",
+	Use:     "start [feature-name...]",
 	Short:   "Start a new feature (alias: s)", // Will be updated after strings load
 	Aliases: []string{"s"},
-	Args:    cobra.ExactArgs(1), // 要求提供一个参数
+	Args:    cobra.MinimumNArgs(1), // 多个参数会以空格拼接为名称
+	// 补全分支类型前缀，如 "chore:"
+	ValidArgsFunction: completeBranchTypes,
 	Run: func(cmd *cobra.Command, args []string) {
 		config := utils.ReadConfig()
 
 		if config == nil {
 			return
 		}
+		autostash, ok := getAutostashMode(cmd, config)
+		if !ok {
+			return
+		}
 
-		startName := parseStartName(args[0])
-		branchName := utils.GenerateBranchName(config, startName.ActionName, startName.FeatureName)
+		startName := parseStartName(str.Join(args, " "))
+		branchType, _ := utils.GetBranchType(config, startName.ActionName)
 
-		// 解析基础分支
+		// 如果名称是 issue 引用（#123 / GH-123），根据 issue 标题生成名称
+		featureName, issue, ok := resolveIssueName(config, startName.FeatureName)
+		if !ok {
+			return
+		}
+		branchName, ok := sanitizeBranchName(config, buildBranchName(config, branchType.Name, featureName, issue))
+		if !ok {
+			return
+		}
+
+		// 解析基础分支：--base 参数 > 分支类型的基础分支（默认 devBaseBranch）
 		baseBranch := determineBaseBranch()
 		if baseBranch == "" {
-			baseBranch = config.DevBaseBranch
+			baseBranch = branchType.Base
 		}
 
 		// 执行命令: git fetch origin（始终执行，确保远程信息最新）
@@ -61,15 +77,24 @@ var startCmd = &cobra.Command{
 		// 执行命令: git checkout -b feature/aric/new-feature origin/develop
 		baseRemoteBranch := fmt.Sprintf("origin/%s", baseBranch)
 		checkoutCmd := fmt.Sprintf("git checkout -b %s %s", branchName, baseRemoteBranch)
-		if err := utils.RunCommandWithSpin(checkoutCmd, strings.GetPath("start.creating")); err != nil {
+		created := runWithAutostash(autostash, func() bool {
+			return utils.RunCommandWithSpin(checkoutCmd, strings.GetPath("start.creating")) == nil
+		})
+		if !created {
 			return
 		}
-		utils.Successf(strings.GetPath("start.success", startName.ActionName, branchName))
+
+		// 记录父分支，用于 stack 展示和 restack
+		if err := utils.RecordBranchParent(branchName, baseBranch, baseRemoteBranch); err != nil {
+			utils.Warning(strings.GetPath("stack.record_failed", err))
+		}
+		recordBranchIssue(branchName, issue)
+		utils.Successf(strings.GetPath("start.success", branchType.Name, branchName))
 	},
 }
 
 // determineBaseBranch 确定使用的基础分支
-// 优先级：--base 参数 > config.DevBaseBranch
+// 优先级：--base 参数 > 分支类型的基础分支
 // 如果 --base=@，则返回当前分支名
 func determineBaseBranch() string {
 	// 如果用户指定了 --base 参数
@@ -78,7 +103,7 @@ func determineBaseBranch() string {
 		if startBaseBranch == "@" {
 			currentBranch, err := utils.GetCurrentBranch()
 			if err != nil {
-				// 如果获取失败，返回空字符串（由上层使用 config.DevBaseBranch）
+				// 如果获取失败，返回空字符串（由上层使用分支类型的基础分支）
 				utils.Errorf("Failed to get current branch: %v", err)
 				return ""
 			}
@@ -88,14 +113,90 @@ func determineBaseBranch() string {
 		return startBaseBranch
 	}
 
-	// 没有指定 --base 参数，返回空字符串（由上层使用 config.DevBaseBranch）
+	// 没有指定 --base 参数，返回空字符串（由上层使用分支类型的基础分支）
 	return ""
 }
 
+// completeBranchTypes 为 start 的第一个参数补全 "类型:" 前缀（包括别名）
+func completeBranchTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || str.Contains(toComplete, ":") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	config := utils.ReadConfig()
+	var completions []string
+	for _, branchType := range utils.GetBranchTypes(config) {
+		description := fmt.Sprintf("%s/… (base: %s)", branchType.Prefix, branchType.Base)
+		for _, name := range append([]string{branchType.Name}, branchType.Aliases...) {
+			if str.HasPrefix(name, toComplete) {
+				completions = append(completions, fmt.Sprintf("%s:\t%s", name, description))
+			}
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
+
+// resolveIssueName 解析 issue 引用（#123 或 GH-123）
+// 如果名称是 issue 引用，则查询 issue 并返回 issue 标题生成的名称；
+// 否则原样返回名称。查询失败时返回 ok=false
+func resolveIssueName(config *utils.YamlConfig, name string) (string, *utils.Issue, bool) {
+	id, isIssue := utils.ParseIssueRef(name)
+	if !isIssue {
+		return name, nil, true
+	}
+
+	issue, err := utils.LookupIssue(config, id)
+	if err != nil {
+		utils.Errorf(strings.GetPath("start.issue_lookup_failed", id, err))
+		return "", nil, false
+	}
+	utils.Info(strings.GetPath("start.issue_found", issue.ID, issue.Title))
+
+	return utils.Slugify(issue.Title), issue, true
+}
+
+// buildBranchName 根据分支模板生成分支名，issue 编号填入 {issue} 占位符
+// （模板中没有 {issue} 时会作为名称前缀）
+func buildBranchName(config *utils.YamlConfig, branchType string, name string, issue *utils.Issue) string {
+	parts := utils.BranchParts{Type: branchType, Name: name}
+	if issue != nil {
+		parts.Issue = issue.ID
+		if parts.Name == "" {
+			parts.Name = issue.ID
+			parts.Issue = ""
+		}
+	}
+	return utils.GenerateBranchNameFromParts(config, parts)
+}
+
+// sanitizeBranchName 清理分支名中 git 不允许的字符，名称有变化时显示清理后的结果
+func sanitizeBranchName(config *utils.YamlConfig, branchName string) (string, bool) {
+	cleaned, err := utils.SanitizeBranchName(config, branchName)
+	if err != nil {
+		utils.Error(strings.GetPath("branch_name.invalid", branchName, err))
+		return "", false
+	}
+	if cleaned != branchName {
+		utils.Info(strings.GetPath("branch_name.cleaned", branchName, cleaned))
+	}
+	return cleaned, true
+}
+
+// recordBranchIssue 记录分支对应的 issue，用于生成 PR 描述
+func recordBranchIssue(branchName string, issue *utils.Issue) {
+	if issue == nil {
+		return
+	}
+	if err := utils.RecordBranchIssue(branchName, issue); err != nil {
+		utils.Warning(strings.GetPath("start.issue_record_failed", err))
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(startCmd)
 	// 添加 --base flag
 	startCmd.Flags().StringVarP(&startBaseBranch, "base", "b", "", strings.GetPath("start.base_flag"))
+	addAutostashFlag(startCmd)
 }
 
 type StartName struct {
@@ -106,7 +207,7 @@ type StartName struct {
 func parseStartName(name string) *StartName {
 	hasColon := str.Contains(name, ":")
 	if hasColon {
-		parts := str.Split(name, ":")
+		parts := str.SplitN(name, ":", 2)
 		return &StartName{
 			ActionName:  parts[0],
 			FeatureName: parts[1],
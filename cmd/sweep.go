@@ -4,30 +4,116 @@ import (
   "fmt"
   "gfl/utils"
   "gfl/utils/strings"
-  "os/exec"
+  "path"
+  "regexp"
+  "sort"
   str "strings"
+  "time"
 
+  "github.com/AlecAivazis/survey/v2"
   "github.com/fatih/color"
   "github.com/spf13/cobra"
 )
 
 var (
-  localFlag    bool
-  remoteFlag   bool
-  exactFlag    bool
-  forceFlag    bool
+  localFlag       bool
+  remoteFlag      bool
+  exactFlag       bool
+  forceFlag       bool
+  mineFlag        bool
+  mergedFlag      bool
+  staleFlag       string
+  authorFlag      string
+  interactiveFlag bool
+  globFlag        bool
+  regexFlag       bool
+  excludeFlags    []string
 )
 
+// sweepFilter 描述要清理哪些分支，关键词和各个筛选标志同时生效。
+// 本地和远程分支都按不含远程名的分支名匹配（如 feature/aric/login）
+type sweepFilter struct {
+  keyword  string
+  exact    bool
+  glob     bool
+  regex    *regexp.Regexp
+  excludes []string
+  mine     bool
+  merged   bool
+  stale    time.Duration
+  author   string
+}
+
+// sweepCandidate 是交互式清理中的一个分支，本地和远程的同名分支合并为一项
+type sweepCandidate struct {
+  name   string
+  local  *utils.BranchDetails
+  remote *utils.BranchDetails
+  merged *utils.MergedBranch
+}
+
 var sweepCmd = &cobra.Command{
   Use:     "sweep [keyword]",
   Aliases: []string{"clean", "rm"},
   Short:   "Clean branches containing specific keywords (alias: clean, rm)",
-  Args:    cobra.ExactArgs(1), // 需要一个关键词参数
+  Args:    cobra.MaximumNArgs(1), // 关键词参数（使用 -i、--mine、--merged、--stale 或 --author 时可省略）
   Run: func(cmd *cobra.Command, args []string) {
-    keyword := args[0]
+    filter := sweepFilter{exact: exactFlag, glob: globFlag, excludes: excludeFlags, mine: mineFlag, merged: mergedFlag, author: authorFlag}
+    if len(args) > 0 {
+      filter.keyword = args[0]
+    }
+
+    // --regex: 关键词作为正则表达式
+    if regexFlag && filter.keyword != "" {
+      regex, err := regexp.Compile(filter.keyword)
+      if err != nil {
+        utils.Error(strings.GetPath("sweep.regex_invalid", err))
+        return
+      }
+      filter.regex = regex
+    }
+
+    // 检查 --glob 和 --exclude 的通配符语法
+    patterns := excludeFlags
+    if globFlag && filter.keyword != "" {
+      patterns = append([]string{filter.keyword}, excludeFlags...)
+    }
+    for _, pattern := range patterns {
+      if _, err := path.Match(pattern, ""); err != nil {
+        utils.Error(strings.GetPath("sweep.glob_invalid", pattern, err))
+        return
+      }
+    }
     // get flag confirm
     confirm, _ := cmd.Flags().GetBool("confirm")
 
+    // 没有关键词时必须使用其他筛选条件
+    if filter.keyword == "" && !interactiveFlag && !mineFlag && !mergedFlag && staleFlag == "" && authorFlag == "" {
+      utils.Error(strings.GetPath("sweep.keyword_required"))
+      return
+    }
+
+    if staleFlag != "" {
+      stale, err := utils.ParseAge(staleFlag)
+      if err != nil {
+        utils.Error(strings.GetPath("sweep.stale_invalid", err))
+        return
+      }
+      filter.stale = stale
+    }
+
+    config := utils.ReadConfig()
+    if mineFlag && config.Nickname == "" {
+      utils.Error(strings.GetPath("sweep.nickname_required"))
+      return
+    }
+
+    // -i: 交互式选择要删除的分支，不需要 -y
+    if interactiveFlag {
+      runInteractiveSweep(config, filter, forceFlag)
+      return
+    }
+
     // 如果没有设置本地或远程标志，打印错误并返回
     if !localFlag && !remoteFlag {
       utils.Error(strings.GetPath("sweep.local_remote_required"))
@@ -36,12 +122,12 @@ var sweepCmd = &cobra.Command{
 
     if localFlag {
       // 清理本地分支
-      cleanLocalBranches(keyword, confirm, exactFlag, forceFlag)
+      cleanLocalBranches(config, filter, confirm, forceFlag)
     }
 
     if remoteFlag {
       // 清理远程分支
-      cleanRemoteBranches(keyword, confirm, exactFlag)
+      cleanRemoteBranches(config, filter, confirm)
     }
 
     if !confirm {
@@ -50,27 +136,58 @@ var sweepCmd = &cobra.Command{
   },
 }
 
-func cleanLocalBranches(keyword string, confirm bool, exactMatch bool, force bool) {
+func cleanLocalBranches(config *utils.YamlConfig, filter sweepFilter, confirm bool, force bool) {
   // 获取本地分支列表
-  branches, err := exec.Command("git", "branch").Output()
+  branches, err := utils.GetLocalBranchNames()
   if err != nil {
     utils.Errorf(strings.GetPath("sweep.local_branches_error", err))
     return
   }
+  current, _ := utils.GetCurrentBranch()
 
-  // 遍历本地分支列表并删除匹配关键词的分支
-  for _, branch := range str.Split(string(branches), "\n") {
-    branch = str.TrimSpace(branch) // 去除空格
-    if branch == "" {
-      continue // 跳过空行
+  // --stale / --author: 按最后一次提交的时间和作者筛选
+  var details map[string]utils.BranchDetails
+  if filter.stale > 0 || filter.author != "" {
+    if details, err = utils.ListBranchDetails(false); err != nil {
+      utils.Errorf(strings.GetPath("sweep.local_branches_error", err))
+      return
     }
+  }
 
-    // 根据精确匹配标志选择匹配方式
-    var shouldDelete bool
-    if exactMatch {
-      shouldDelete = branch == keyword
-    } else {
-      shouldDelete = str.Contains(branch, keyword)
+  // --merged: 只清理已合并到开发基础分支或生产分支的分支，只有符合其他筛选条件的分支才比较补丁
+  var mergedBranches map[string]utils.MergedBranch
+  if filter.merged {
+    if mergedBranches, err = utils.GetMergedBranches(config, false, filter.matchingNames(config, branches, details)); err != nil {
+      utils.Error(strings.GetPath("sweep.merged_error", err))
+      return
+    }
+  }
+
+  // 遍历本地分支列表并删除匹配的分支
+  for _, branch := range branches {
+    // 不清理当前分支
+    if branch == current {
+      continue
+    }
+
+    // 按关键词、--glob/--regex、--exclude、sweepKeep 和 --mine 匹配分支名
+    shouldDelete := filter.matchesName(config, branch)
+
+    // --merged: 跳过未合并的分支
+    mergedBranch, isMerged := mergedBranches[branch]
+    if filter.merged && !isMerged {
+      shouldDelete = false
+    }
+
+    // --stale / --author: 跳过最近有提交或作者不符的分支
+    detail, hasDetail := details[branch]
+    if details != nil && !(hasDetail && filter.matchesDetails(config, detail)) {
+      shouldDelete = false
+    }
+
+    // 受保护的分支（如 main、dev）不清理
+    if shouldDelete && !checkProtectedBranch(config, branch) {
+      shouldDelete = false
     }
 
     if shouldDelete {
@@ -79,67 +196,418 @@ func cleanLocalBranches(keyword string, confirm bool, exactMatch bool, force boo
       if force {
         deleteFlag = "-D"
       }
+      if filter.merged {
+        logMerged(branch, mergedBranch)
+        if !force && skipUncertainMerge(branch, mergedBranch, confirm) {
+          continue
+        }
+        // 已对照基础分支确认合并，使用 -D：-d 只对照 HEAD 或上游检查，
+        // 在其他分支上运行或 rebase/squash 合并时会失败
+        deleteFlag = "-D"
+      }
+      if hasDetail {
+        logDetails(branch, detail, utils.DevBaseRef(config, false))
+      }
       command := fmt.Sprintf("git branch %s %s", deleteFlag, branch)
       if confirm {
-        if err := utils.RunCommandWithSpin(command, strings.GetPath("sweep.deleting_local")); err != nil {
+        if err := utils.RunTrashedDelete(branch, false, command, strings.GetPath("sweep.deleting_local")); err != nil {
           utils.Errorf(strings.GetPath("sweep.delete_local_error", branch, err))
         } else {
           utils.Successf(strings.GetPath("sweep.delete_local_success", branch))
         }
-      } else {
-        logRemove(branch, keyword)
+      } else if !filter.merged && !hasDetail {
+        logRemove(branch, filter.keyword)
       }
     }
   }
 }
 
-func cleanRemoteBranches(keyword string, confirm bool, exactMatch bool) {
+func cleanRemoteBranches(config *utils.YamlConfig, filter sweepFilter, confirm bool) {
   // 获取远程分支列表
-  branches, err := exec.Command("git", "branch", "-r").Output()
+  branches, err := utils.GetRemoteBranches()
   if err != nil {
     utils.Errorf(strings.GetPath("sweep.remote_branches_error", err))
     return
   }
 
-  // 遍历远程分支列表并删除匹配关键词的分支
-  for _, branch := range str.Split(string(branches), "\n") {
-    branch = str.TrimSpace(branch) // 去除空格
-    if branch == "" {
-      continue // 跳过空行
+  // --stale / --author: 按最后一次提交的时间和作者筛选
+  var details map[string]utils.BranchDetails
+  if filter.stale > 0 || filter.author != "" {
+    if details, err = utils.ListBranchDetails(true); err != nil {
+      utils.Errorf(strings.GetPath("sweep.remote_branches_error", err))
+      return
+    }
+  }
+
+  // --merged: 只清理已合并到 origin 上开发基础分支或生产分支的分支，只有符合其他筛选条件的分支才比较补丁
+  var mergedBranches map[string]utils.MergedBranch
+  if filter.merged {
+    var names []string
+    for _, branch := range branches {
+      if name, isOrigin := str.CutPrefix(branch, "origin/"); isOrigin {
+        names = append(names, name)
+      }
+    }
+    if mergedBranches, err = utils.GetMergedBranches(config, true, filter.matchingNames(config, names, details)); err != nil {
+      utils.Error(strings.GetPath("sweep.merged_error", err))
+      return
+    }
+  }
+
+  // 遍历远程分支列表并删除匹配的分支
+  for _, branch := range branches {
+    // 只清理 origin 上的分支，匹配时使用去掉远程名的分支名
+    remoteBranch, isOrigin := str.CutPrefix(branch, "origin/")
+    if !isOrigin {
+      continue
     }
 
-    // 提取分支名称（去掉远程名）
-    remoteBranch := str.TrimPrefix(branch, "origin/")
+    // 按关键词、--glob/--regex、--exclude、sweepKeep 和 --mine 匹配分支名
+    shouldDelete := filter.matchesName(config, remoteBranch)
 
-    // 根据精确匹配标志选择匹配方式
-    var shouldDelete bool
-    if exactMatch {
-      shouldDelete = remoteBranch == keyword
-    } else {
-      shouldDelete = str.Contains(branch, keyword)
+    // --merged: 跳过未合并的分支
+    mergedBranch, isMerged := mergedBranches[remoteBranch]
+    if filter.merged && !isMerged {
+      shouldDelete = false
+    }
+
+    // --stale / --author: 跳过最近有提交或作者不符的分支
+    detail, hasDetail := details[remoteBranch]
+    if details != nil && !(hasDetail && filter.matchesDetails(config, detail)) {
+      shouldDelete = false
+    }
+
+    // 受保护的分支（如 main、dev）不清理
+    if shouldDelete && !checkProtectedBranch(config, remoteBranch) {
+      shouldDelete = false
     }
 
     if shouldDelete {
       command := fmt.Sprintf("git push origin --delete %s", remoteBranch)
+      if filter.merged {
+        logMerged(branch, mergedBranch)
+        if !forceFlag && skipUncertainMerge(branch, mergedBranch, confirm) {
+          continue
+        }
+      }
+      if hasDetail {
+        logDetails(branch, detail, utils.DevBaseRef(config, true))
+      }
       if confirm {
-        if err := utils.RunCommandWithSpin(command, strings.GetPath("sweep.deleting_remote")); err != nil {
+        if err := utils.RunTrashedDelete(remoteBranch, true, command, strings.GetPath("sweep.deleting_remote")); err != nil {
           utils.Errorf(strings.GetPath("sweep.delete_remote_error", branch, err))
         } else {
           utils.Successf(strings.GetPath("sweep.delete_remote_success", branch))
         }
+      } else if !filter.merged && !hasDetail {
+        logRemove(branch, filter.keyword)
+      }
+    }
+  }
+}
+
+// runInteractiveSweep 列出符合条件的分支供多选，已合并的分支默认选中，只删除选中的分支（本地和远程一起）
+func runInteractiveSweep(config *utils.YamlConfig, filter sweepFilter, force bool) {
+  // 没有指定 -l/-r 时同时列出本地和远程分支
+  includeLocal := localFlag || !remoteFlag
+  includeRemote := remoteFlag || !localFlag
+  candidates, err := collectSweepCandidates(config, filter, includeLocal, includeRemote)
+  if err != nil {
+    utils.Error(strings.GetPath("sweep.interactive.collect_error", err))
+    return
+  }
+  if len(candidates) == 0 {
+    utils.Info(strings.GetPath("sweep.interactive.none"))
+    return
+  }
+
+  // PR 状态只在 gh 可用时显示
+  prs, _ := utils.GetPullRequestsByBranch()
+
+  var options, defaults []string
+  for _, candidate := range candidates {
+    options = append(options, candidate.name)
+    if candidate.merged != nil && candidate.merged.Confidence != utils.MergeConfidenceMedium {
+      defaults = append(defaults, candidate.name)
+    }
+  }
+
+  var selected []int
+  if err := survey.AskOne(&survey.MultiSelect{
+    Message:  strings.GetPath("sweep.interactive.select"),
+    Options:  options,
+    Default:  defaults,
+    PageSize: 15,
+    Description: func(value string, index int) string {
+      return describeSweepCandidate(config, candidates[index], prs)
+    },
+  }, &selected); err != nil {
+    utils.Error(strings.GetPath("sweep.interactive.cancelled"))
+    return
+  }
+  if len(selected) == 0 {
+    utils.Info(strings.GetPath("sweep.interactive.none_selected"))
+    return
+  }
+
+  for _, index := range selected {
+    deleteSweepCandidate(candidates[index], force)
+  }
+}
+
+// collectSweepCandidates 按筛选条件收集本地和远程分支，跳过当前分支和受保护的分支
+func collectSweepCandidates(config *utils.YamlConfig, filter sweepFilter, includeLocal bool, includeRemote bool) ([]sweepCandidate, error) {
+  current, _ := utils.GetCurrentBranch()
+  byName := map[string]*sweepCandidate{}
+
+  for _, remote := range []bool{false, true} {
+    if (remote && !includeRemote) || (!remote && !includeLocal) {
+      continue
+    }
+    details, err := utils.ListBranchDetails(remote)
+    if err != nil {
+      return nil, err
+    }
+
+    matching := map[string]utils.BranchDetails{}
+    names := []string{}
+    for name, detail := range details {
+      if name == current || !filter.matchesName(config, name) {
+        continue
+      }
+      if (filter.stale > 0 || filter.author != "") && !filter.matchesDetails(config, detail) {
+        continue
+      }
+      if _, protected := utils.IsProtectedBranch(config, name); protected && !forceProtectedFlag {
+        continue
+      }
+      matching[name] = detail
+      names = append(names, name)
+    }
+
+    // 只对列出的分支比较补丁
+    mergedBranches, err := utils.GetMergedBranches(config, remote, names)
+    if err != nil {
+      // 没有基础分支时仍然可以选择，只是不会预先选中
+      mergedBranches = nil
+    }
+
+    for name, detail := range matching {
+      candidate, found := byName[name]
+      if !found {
+        candidate = &sweepCandidate{name: name}
+        byName[name] = candidate
+      }
+      detail := detail
+      if remote {
+        candidate.remote = &detail
       } else {
-        logRemove(branch, keyword)
+        candidate.local = &detail
+      }
+      if merged, ok := mergedBranches[name]; ok && candidate.merged == nil {
+        candidate.merged = &merged
       }
     }
   }
+
+  var candidates []sweepCandidate
+  for _, candidate := range byName {
+    if filter.merged && candidate.merged == nil {
+      continue
+    }
+    candidates = append(candidates, *candidate)
+  }
+  sort.Slice(candidates, func(i, j int) bool { return candidates[i].name < candidates[j].name })
+  return candidates, nil
+}
+
+// describeSweepCandidate 生成多选列表中每个分支的说明：位置、最后一次提交、上游状态、PR 状态和合并情况
+func describeSweepCandidate(config *utils.YamlConfig, candidate sweepCandidate, prs map[string]*utils.PullRequestStatus) string {
+  var parts []string
+  detail := candidate.local
+  switch {
+  case candidate.local != nil && candidate.remote != nil:
+    parts = append(parts, strings.GetPath("sweep.interactive.local_and_remote"))
+  case candidate.local != nil:
+    parts = append(parts, strings.GetPath("sweep.interactive.local_only"))
+  default:
+    parts = append(parts, strings.GetPath("sweep.interactive.remote_only"))
+    detail = candidate.remote
+  }
+
+  parts = append(parts, fmt.Sprintf("%s %s", utils.FormatAge(detail.LastCommit), detail.AuthorName))
+
+  if candidate.local != nil {
+    switch {
+    case candidate.local.UpstreamGone:
+      parts = append(parts, strings.GetPath("sweep.interactive.upstream_gone"))
+    case candidate.local.Upstream == "" && candidate.remote == nil:
+      parts = append(parts, strings.GetPath("sweep.interactive.no_upstream"))
+    case candidate.local.Upstream == "":
+      // 已推送但没有设置上游
+    case candidate.local.UpstreamAhead > 0 || candidate.local.UpstreamBehind > 0:
+      parts = append(parts, fmt.Sprintf("%s ↑%d ↓%d", candidate.local.Upstream, candidate.local.UpstreamAhead, candidate.local.UpstreamBehind))
+    default:
+      parts = append(parts, strings.GetPath("sweep.interactive.upstream_synced", candidate.local.Upstream))
+    }
+  }
+
+  if pr, found := prs[candidate.name]; found {
+    parts = append(parts, fmt.Sprintf("PR #%d %s", pr.Number, pr.State))
+  }
+
+  if candidate.merged != nil {
+    parts = append(parts, strings.GetPath("sweep.merge_label", strings.GetPath("sweep.merge_kind."+candidate.merged.Kind), strings.GetPath("sweep.confidence."+candidate.merged.Confidence)))
+  }
+  return str.Join(parts, " · ")
+}
+
+// deleteSweepCandidate 删除选中分支的本地和远程副本
+func deleteSweepCandidate(candidate sweepCandidate, force bool) {
+  if candidate.local != nil {
+    // 用户已确认选择，已合并（包括 squash/rebase 合并）的分支使用 -D
+    deleteFlag := "-d"
+    if force || candidate.merged != nil {
+      deleteFlag = "-D"
+    }
+    command := fmt.Sprintf("git branch %s %s", deleteFlag, candidate.name)
+    if err := utils.RunTrashedDelete(candidate.name, false, command, strings.GetPath("sweep.deleting_local")); err != nil {
+      utils.Errorf(strings.GetPath("sweep.delete_local_error", candidate.name, err))
+    } else {
+      utils.Successf(strings.GetPath("sweep.delete_local_success", candidate.name))
+    }
+  }
+
+  if candidate.remote != nil {
+    command := fmt.Sprintf("git push origin --delete %s", candidate.name)
+    if err := utils.RunTrashedDelete(candidate.name, true, command, strings.GetPath("sweep.deleting_remote")); err != nil {
+      utils.Errorf(strings.GetPath("sweep.delete_remote_error", "origin/"+candidate.name, err))
+    } else {
+      utils.Successf(strings.GetPath("sweep.delete_remote_success", "origin/"+candidate.name))
+    }
+  }
+}
+
+// matchesName 检查分支名（不含远程名）是否满足关键词、--exclude、sweepKeep 和 --mine
+func (f sweepFilter) matchesName(config *utils.YamlConfig, name string) bool {
+  switch {
+  case f.keyword == "":
+  case f.exact:
+    if name != f.keyword {
+      return false
+    }
+  case f.glob:
+    if !utils.MatchBranchGlob(f.keyword, name) {
+      return false
+    }
+  case f.regex != nil:
+    if !f.regex.MatchString(name) {
+      return false
+    }
+  case !str.Contains(name, f.keyword):
+    return false
+  }
+
+  for _, pattern := range f.excludes {
+    if utils.MatchBranchGlob(pattern, name) {
+      return false
+    }
+  }
+  // sweepKeep 中的分支总是保留
+  if utils.IsKeptBranch(config, name) {
+    return false
+  }
+  return !f.mine || isMineBranch(config, name)
+}
+
+// matchingNames 返回满足名称筛选条件以及 --stale/--author（details 不为 nil 时）的分支名，
+// 用于限制需要比较补丁的分支
+func (f sweepFilter) matchingNames(config *utils.YamlConfig, names []string, details map[string]utils.BranchDetails) []string {
+  matching := []string{}
+  for _, name := range names {
+    if !f.matchesName(config, name) {
+      continue
+    }
+    if details != nil {
+      if detail, found := details[name]; !found || !f.matchesDetails(config, detail) {
+        continue
+      }
+    }
+    matching = append(matching, name)
+  }
+  return matching
+}
+
+// matchesDetails 检查分支的最后一次提交是否满足 --stale 和 --author
+func (f sweepFilter) matchesDetails(config *utils.YamlConfig, detail utils.BranchDetails) bool {
+  if f.stale > 0 && time.Since(detail.LastCommit) < f.stale {
+    return false
+  }
+  if f.author == "" {
+    return true
+  }
+  // 包含 @ 时按作者邮箱匹配，否则按分支名中的昵称匹配
+  if str.Contains(f.author, "@") {
+    return str.EqualFold(detail.AuthorEmail, f.author)
+  }
+  parts, ok := utils.ParseBranchName(config, detail.Name)
+  return ok && parts.Nickname == f.author
+}
+
+// logDetails 删除前显示分支最后一次提交的时间、作者以及相对开发基础分支的领先/落后提交数
+func logDetails(branch string, detail utils.BranchDetails, base string) {
+  position := "-"
+  if base != "" {
+    if ahead, behind, err := utils.CountAheadBehind(detail.Ref, base); err == nil {
+      position = strings.GetPath("sweep.position", base, ahead, behind)
+    }
+  }
+  lastCommit := fmt.Sprintf("%s (%s)", detail.LastCommit.Format("2006-01-02"), utils.FormatAge(detail.LastCommit))
+  author := fmt.Sprintf("%s <%s>", detail.AuthorName, detail.AuthorEmail)
+  utils.Info(strings.GetPath("sweep.branch_details", color.GreenString(branch), color.YellowString(lastCommit), author, position))
+}
+
+// logMerged 显示分支合并到了哪个基础分支、带入该分支的提交以及判断的置信度
+func logMerged(branch string, merged utils.MergedBranch) {
+  commit := "-"
+  if merged.MergeCommit != "" {
+    commit = color.YellowString(merged.MergeCommit) + " " + merged.MergeSubject
+  }
+  label := strings.GetPath("sweep.merge_label", strings.GetPath("sweep.merge_kind."+merged.Kind), strings.GetPath("sweep.confidence."+merged.Confidence))
+  if merged.Confidence == utils.MergeConfidenceMedium {
+    label = color.YellowString(label)
+  }
+  utils.Info(strings.GetPath("sweep.merged_into", color.GreenString(branch), color.CyanString(merged.Base), commit, label))
+}
+
+// skipUncertainMerge 只按内容判断为已合并的分支需要 -f 才会删除，返回 true 表示跳过
+func skipUncertainMerge(branch string, merged utils.MergedBranch, confirm bool) bool {
+  if merged.Confidence != utils.MergeConfidenceMedium {
+    return false
+  }
+  if confirm {
+    utils.Warning(strings.GetPath("sweep.uncertain_skipped", branch))
+  }
+  return true
 }
 
 func logRemove(branch string, keyword string) {
+  if keyword == "" {
+    // --mine without keyword
+    utils.Info(strings.GetPath("sweep.manual_delete_mine", color.GreenString(branch)))
+    return
+  }
   colorBranch := color.GreenString(branch)
   colorKeyword := color.RedString(keyword)
   // list branches without confirm
   msg := strings.GetPath("sweep.manual_delete", colorBranch, colorKeyword)
-  utils.Infof(msg)
+  utils.Info(msg)
+}
+
+// isMineBranch 根据分支模板解析分支名，判断昵称是否为当前配置的昵称
+func isMineBranch(config *utils.YamlConfig, branch string) bool {
+  parts, ok := utils.ParseBranchName(config, branch)
+  return ok && parts.Nickname == config.Nickname
 }
 
 func init() {
@@ -147,5 +615,15 @@ func init() {
   sweepCmd.Flags().BoolVarP(&remoteFlag, "remote", "r", false, strings.GetPath("sweep.remote_flag"))
   sweepCmd.Flags().BoolVarP(&exactFlag, "exact", "e", false, strings.GetPath("sweep.exact_flag"))
   sweepCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, strings.GetPath("sweep.force_flag"))
+  sweepCmd.Flags().BoolVarP(&mineFlag, "mine", "m", false, strings.GetPath("sweep.mine_flag"))
+  sweepCmd.Flags().BoolVar(&mergedFlag, "merged", false, strings.GetPath("sweep.merged_flag"))
+  sweepCmd.Flags().StringVar(&staleFlag, "stale", "", strings.GetPath("sweep.stale_flag"))
+  sweepCmd.Flags().StringVar(&authorFlag, "author", "", strings.GetPath("sweep.author_flag"))
+  sweepCmd.Flags().BoolVarP(&interactiveFlag, "interactive", "i", false, strings.GetPath("sweep.interactive_flag"))
+  sweepCmd.Flags().BoolVarP(&globFlag, "glob", "g", false, strings.GetPath("sweep.glob_flag"))
+  sweepCmd.Flags().BoolVarP(&regexFlag, "regex", "x", false, strings.GetPath("sweep.regex_flag"))
+  sweepCmd.Flags().StringArrayVar(&excludeFlags, "exclude", nil, strings.GetPath("sweep.exclude_flag"))
+  sweepCmd.MarkFlagsMutuallyExclusive("exact", "glob", "regex")
+  addForceProtectedFlag(sweepCmd)
   rootCmd.AddCommand(sweepCmd)
 }
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"fmt"
 	"gfl/utils"
 	"gfl/utils/strings"
+	"sort"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +17,9 @@ var syncCmd = &cobra.Command{
 	Aliases: []string{"up"},
 	Short:   "Sync remote repository to local repository/update all remote repository references", // Will be updated after strings load
 	Run: func(cmd *cobra.Command, args []string) {
+		pruneLocal, _ := cmd.Flags().GetBool("prune-local")
+		confirm, _ := cmd.Flags().GetBool("confirm")
+
 		if err := utils.RunCommandWithSpin("git fetch origin", strings.GetPath("sync.fetching")); err == nil {
 			utils.Success(strings.GetPath("sync.fetch_success"))
 		}
@@ -20,9 +27,94 @@ var syncCmd = &cobra.Command{
 		if err := utils.RunCommandWithSpin("git remote update origin --prune", strings.GetPath("sync.updating")); err == nil {
 			utils.Success(strings.GetPath("sync.sync_success"))
 		}
+
+		if pruneLocal {
+			config := utils.ReadConfig()
+			if config == nil {
+				return
+			}
+			pruneGoneBranches(config, confirm)
+		}
 	},
 }
 
+// pruneGoneBranches 删除上游已被删除（[gone]）的本地分支
+// 已合并的分支直接删除，未合并（或只按内容判断为已合并）的分支逐个询问
+func pruneGoneBranches(config *utils.YamlConfig, confirm bool) {
+	details, err := utils.ListBranchDetails(false)
+	if err != nil {
+		utils.Errorf(strings.GetPath("sync.prune_list_error", err))
+		return
+	}
+
+	var gone []string
+	for name, detail := range details {
+		if detail.UpstreamGone {
+			gone = append(gone, name)
+		}
+	}
+	if len(gone) == 0 {
+		utils.Info(strings.GetPath("sync.prune_none"))
+		return
+	}
+	sort.Strings(gone)
+
+	currentBranch, _ := utils.GetCurrentBranch()
+	for _, branch := range gone {
+		upstream := color.HiBlackString(details[branch].Upstream)
+		if branch == currentBranch {
+			utils.Warning(strings.GetPath("sync.prune_current", branch, upstream))
+			continue
+		}
+		if !checkProtectedBranch(config, branch) {
+			continue
+		}
+
+		merged, isMerged := utils.CheckBranchMerged(config, branch)
+		certain := isMerged && merged.Confidence != utils.MergeConfidenceMedium
+		if isMerged {
+			logMerged(branch, merged)
+		} else {
+			logDetails(branch, details[branch], utils.DevBaseRef(config, false))
+		}
+
+		// 预览模式只列出分支
+		if !confirm {
+			if certain {
+				utils.Info(strings.GetPath("sync.prune_would_delete", color.GreenString(branch), upstream))
+			} else {
+				utils.Info(strings.GetPath("sync.prune_would_ask", color.GreenString(branch), upstream))
+			}
+			continue
+		}
+
+		if !certain {
+			var remove bool
+			if err := survey.AskOne(&survey.Confirm{
+				Message: strings.GetPath("sync.prune_ask", branch),
+				Default: false,
+			}, &remove); err != nil || !remove {
+				utils.Info(strings.GetPath("sync.prune_kept", branch))
+				continue
+			}
+		}
+
+		// 上游已不存在时 git branch -d 会与 HEAD 比较，因此使用 -D，分支仍可从回收站恢复
+		command := fmt.Sprintf("git branch -D %s", branch)
+		if err := utils.RunTrashedDelete(branch, false, command, strings.GetPath("sweep.deleting_local")); err != nil {
+			utils.Errorf(strings.GetPath("sweep.delete_local_error", branch, err))
+			continue
+		}
+		utils.Success(strings.GetPath("sweep.delete_local_success", branch))
+	}
+
+	if !confirm {
+		utils.Info(strings.GetPath("sync.prune_skip_confirm"))
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().Bool("prune-local", false, strings.GetPath("sync.prune_local_flag"))
+	addForceProtectedFlag(syncCmd)
 }
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"gfl/utils"
+	"gfl/utils/strings"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// trashCmd represents the trash command
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage deleted branches", // Will be updated after strings load
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		trashListCmd.Run(cmd, args)
+	},
+}
+
+// trashListCmd represents the trash list command
+var trashListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List deleted branches", // Will be updated after strings load
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries, err := utils.ListTrash()
+		if err != nil {
+			utils.Errorf(strings.GetPath("trash.list_error", err))
+			return
+		}
+		if len(entries) == 0 {
+			utils.Info(strings.GetPath("trash.empty"))
+			return
+		}
+		printTrashEntries(entries)
+		utils.Info(strings.GetPath("trash.restore_hint"))
+	},
+}
+
+// trashRestoreCmd represents the trash restore command
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <branch>",
+	Short: "Recreate a deleted branch", // Will be updated after strings load
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		push, _ := cmd.Flags().GetBool("push")
+
+		entry, found := utils.FindTrash(args[0])
+		if !found {
+			utils.Errorf(strings.GetPath("trash.not_found", args[0]))
+			return
+		}
+		if err := utils.RestoreTrash(entry, push); err != nil {
+			utils.Errorf(strings.GetPath("trash.restore_error", entry.Branch, err))
+			return
+		}
+
+		if push {
+			utils.Successf(strings.GetPath("trash.restored_pushed", entry.Branch, shortSHA(entry.SHA)))
+		} else {
+			utils.Successf(strings.GetPath("trash.restored", entry.Branch, shortSHA(entry.SHA)))
+		}
+	},
+}
+
+// trashPurgeCmd represents the trash purge command
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently remove deleted branches", // Will be updated after strings load
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		olderThanFlag, _ := cmd.Flags().GetString("older-than")
+
+		// 未指定 --older-than 时清空整个回收站
+		var olderThan time.Duration
+		if olderThanFlag != "" {
+			age, err := utils.ParseAge(olderThanFlag)
+			if err != nil {
+				utils.Errorf(strings.GetPath("trash.older_than_invalid", olderThanFlag))
+				return
+			}
+			olderThan = age
+		}
+
+		// 未确认时只预览将被清除的分支
+		if !confirm {
+			entries, err := utils.ListTrash()
+			if err != nil {
+				utils.Errorf(strings.GetPath("trash.list_error", err))
+				return
+			}
+			var expired []utils.TrashEntry
+			for _, entry := range entries {
+				if time.Since(entry.DeletedAt) >= olderThan {
+					expired = append(expired, entry)
+				}
+			}
+			if len(expired) == 0 {
+				utils.Info(strings.GetPath("trash.nothing_to_purge"))
+				return
+			}
+			printTrashEntries(expired)
+			utils.Info(strings.GetPath("trash.skip_confirm"))
+			return
+		}
+
+		purged, err := utils.PurgeTrash(olderThan)
+		if err != nil {
+			utils.Errorf(strings.GetPath("trash.purge_error", err))
+		}
+		if len(purged) == 0 && err == nil {
+			utils.Info(strings.GetPath("trash.nothing_to_purge"))
+			return
+		}
+		utils.Successf(strings.GetPath("trash.purged", len(purged)))
+	},
+}
+
+// printTrashEntries 以表格显示回收站中的分支
+func printTrashEntries(entries []utils.TrashEntry) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleRounded)
+
+	header := color.New(color.FgCyan, color.Bold)
+	t.AppendHeader(table.Row{
+		header.Sprint(strings.GetPath("trash.column_branch")),
+		header.Sprint(strings.GetPath("trash.column_scope")),
+		header.Sprint(strings.GetPath("trash.column_commit")),
+		header.Sprint(strings.GetPath("trash.column_upstream")),
+		header.Sprint(strings.GetPath("trash.column_deleted")),
+	})
+
+	for _, entry := range entries {
+		scope := strings.GetPath("trash.scope_local")
+		if entry.Remote {
+			scope = strings.GetPath("trash.scope_remote")
+		}
+		commit := color.YellowString(shortSHA(entry.SHA)) + " " + entry.Subject
+		deleted := entry.DeletedAt.Format("2006-01-02 15:04") + color.HiBlackString(" (%s)", utils.FormatAge(entry.DeletedAt))
+		t.AppendRow(table.Row{color.GreenString(entry.Branch), scope, commit, entry.Upstream, deleted})
+	}
+	t.Render()
+}
+
+// shortSHA 截取提交 SHA 的前 7 位
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func init() {
+	rootCmd.AddCommand(trashCmd)
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashPurgeCmd)
+
+	trashRestoreCmd.Flags().BoolP("push", "p", false, "Push the restored branch to origin again")                // Will be updated after strings load
+	trashPurgeCmd.Flags().String("older-than", "", "Only purge branches deleted longer ago than this, e.g. 30d") // Will be updated after strings load
+}
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/ettle/strcase"
@@ -12,7 +11,7 @@ import (
 //
 // Parameters:
 //   - config: The YAML configuration containing branch type settings
-//   - branchType: The type of branch (e.g., "feature", "fix", "hotfix")
+//   - branchType: The type of branch or one of its aliases (e.g., "feature", "fix", "docs")
 //
 // Returns:
 //   - string: The prefix to be used for the specified branch type
@@ -21,34 +20,18 @@ import (
 //   - feature: "feature"
 //   - fix: "fix"
 //   - hotfix: "hotfix"
+//   - types from branchTypes: their configured prefix, or the type name
 //   - custom: returns the branchType as-is for unknown types
 func GetBranchTypePrefix(config *YamlConfig, branchType string) string {
-	switch branchType {
-	case "feature":
-		if config.FeaturePrefix != "" {
-			return config.FeaturePrefix
-		}
-		return "feature"
-	case "fix":
-		if config.FixPrefix != "" {
-			return config.FixPrefix
-		}
-		return "fix"
-	case "hotfix":
-		if config.HotfixPrefix != "" {
-			return config.HotfixPrefix
-		}
-		return "hotfix"
-	default:
-		// For unknown branch types, return the branchType as-is
-		return branchType
-	}
+	resolved, _ := GetBranchType(config, branchType)
+	return resolved.Prefix
 }
 
 // GenerateBranchName generates a standardized branch name based on configuration.
-// The branch naming follows GitHub Flow conventions with optional developer nickname.
+// The branch naming follows GitHub Flow conventions with optional developer nickname,
+// or the configured branchTemplate when set (see GenerateBranchNameFromParts).
 //
-// Branch naming patterns:
+// Branch naming patterns (default template "{type}/{nickname}/{name}"):
 //   - With nickname: {prefix}/{nickname}/{name}
 //   - Without nickname: {prefix}/{name}
 //
@@ -66,23 +49,7 @@ func GetBranchTypePrefix(config *YamlConfig, branchType string) string {
 //   - Hotfix with nickname: "hotfix/aric/security-fix"
 //   - Custom prefix: "feat/bob/payment-integration"
 func GenerateBranchName(config *YamlConfig, branchType, name string) string {
-	// Get the appropriate prefix for the branch type
-	prefix := GetBranchTypePrefix(config, branchType)
-
-	var branchName string
-	// Include nickname if configured, otherwise use simpler format
-	if config.Nickname != "" {
-		branchName = fmt.Sprintf("%s/%s/%s", prefix, config.Nickname, name)
-	} else {
-		branchName = fmt.Sprintf("%s/%s", prefix, name)
-	}
-
-	// Apply case formatting if configured
-	if config.BranchCaseFormat != "" && config.BranchCaseFormat != "original" {
-		branchName = FormatBranchName(branchName, config.BranchCaseFormat)
-	}
-
-	return branchName
+	return GenerateBranchNameFromParts(config, BranchParts{Type: branchType, Name: name})
 }
 
 // FormatBranchName applies case formatting to a branch name based on configuration.
@@ -151,4 +118,34 @@ func formatString(s, format string) string {
 		// Unknown format, return as-is
 		return s
 	}
-}
\ No newline at end of file
+}
+
+// Slugify converts free text such as an issue title into a branch-friendly slug.
+// Letters and digits are kept (lowercased), every other run of characters
+// becomes a single hyphen.
+//
+// Parameters:
+//   - s: The text to convert
+//
+// Returns:
+//   - string: The slug
+//
+// Examples:
+//   - "Fix login page crash!" -> "fix-login-page-crash"
+//   - "  API: add /users endpoint " -> "api-add-users-endpoint"
+func Slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+		} else {
+			pendingHyphen = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BranchDetails describes the tip of a local or origin remote branch.
+type BranchDetails struct {
+	// Name is the branch name without the remote (e.g., "feature/aric/login")
+	Name string
+
+	// Ref is the full ref (e.g., "refs/remotes/origin/feature/aric/login")
+	Ref string
+
+	// LastCommit is the committer date of the branch tip
+	LastCommit time.Time
+
+	// AuthorName and AuthorEmail identify the author of the branch tip
+	AuthorName  string
+	AuthorEmail string
+
+	// Subject is the subject line of the branch tip
+	Subject string
+
+	// Upstream is the upstream of a local branch (e.g., "origin/feature/aric/login")
+	Upstream string
+
+	// UpstreamGone is true when the upstream is configured but no longer exists
+	UpstreamGone bool
+
+	// UpstreamAhead and UpstreamBehind count the commits the branch has that
+	// its upstream does not, and the other way round
+	UpstreamAhead  int
+	UpstreamBehind int
+}
+
+// ListBranchDetails reads the tip of every local branch, or every origin
+// remote branch, with a single 'git for-each-ref'.
+//
+// Parameters:
+//   - remote: true for the origin remote branches, false for local branches
+//
+// Returns:
+//   - map[string]BranchDetails: Branch name (without remote) to its details
+//   - error: Error if git fails
+func ListBranchDetails(remote bool) (map[string]BranchDetails, error) {
+	prefix := "refs/heads/"
+	if remote {
+		prefix = "refs/remotes/origin/"
+	}
+	format := "--format=%(refname)%00%(committerdate:unix)%00%(authorname)%00%(authoremail)%00%(subject)%00%(upstream:short)%00%(upstream:track,nobracket)"
+	output, err := exec.Command("git", "for-each-ref", format, prefix).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	result := map[string]BranchDetails{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 7 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[0], prefix)
+		if name == "HEAD" {
+			continue
+		}
+		unix, _ := strconv.ParseInt(fields[1], 10, 64)
+		details := BranchDetails{
+			Name:         name,
+			Ref:          fields[0],
+			LastCommit:   time.Unix(unix, 0),
+			AuthorName:   fields[2],
+			AuthorEmail:  strings.Trim(fields[3], "<>"),
+			Subject:      fields[4],
+			Upstream:     fields[5],
+			UpstreamGone: fields[6] == "gone",
+		}
+		// The track field reads "ahead 1", "behind 2" or "ahead 1, behind 2"
+		for _, part := range strings.Split(fields[6], ", ") {
+			fmt.Sscanf(part, "ahead %d", &details.UpstreamAhead)
+			fmt.Sscanf(part, "behind %d", &details.UpstreamBehind)
+		}
+		result[name] = details
+	}
+	return result, nil
+}
+
+// CountAheadBehind counts the commits a branch has that base does not
+// (ahead) and the commits base has that the branch does not (behind).
+//
+// Parameters:
+//   - ref: The branch revision
+//   - base: The base revision (e.g., "dev" or "origin/dev")
+//
+// Returns:
+//   - int: Commits ahead of base
+//   - int: Commits behind base
+//   - error: Error if either revision cannot be resolved
+func CountAheadBehind(ref, base string) (int, int, error) {
+	output, err := exec.Command("git", "rev-list", "--left-right", "--count", base+"..."+ref).Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compare %s with %s: %w", ref, base, err)
+	}
+	var ahead, behind int
+	if _, err := fmt.Sscanf(string(output), "%d\t%d", &behind, &ahead); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	return ahead, behind, nil
+}
+
+// DevBaseRef returns the ref of devBaseBranch to compare branches with: the
+// local branch if it exists (and remote is false), otherwise origin/<branch>.
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - remote: true when comparing remote branches
+//
+// Returns:
+//   - string: The ref, or empty string if devBaseBranch does not exist
+func DevBaseRef(config *YamlConfig, remote bool) string {
+	return baseRef(config.DevBaseBranch, remote)
+}
+
+// baseRef resolves a base branch to a local or origin remote ref.
+func baseRef(branch string, remote bool) string {
+	switch {
+	case branch == "":
+		return ""
+	case !remote && LocalBranchExists(branch):
+		return branch
+	case exec.Command("git", "show-ref", "--verify", "--quiet", "refs/remotes/origin/"+branch).Run() == nil:
+		return "origin/" + branch
+	}
+	return ""
+}
+
+// ParseAge parses an age such as "30d", "2w", "12h" or "1y" (days, weeks,
+// hours, years), or any duration time.ParseDuration accepts.
+//
+// Parameters:
+//   - age: The age text
+//
+// Returns:
+//   - time.Duration: The parsed duration
+//   - error: Error if the text is not a positive age
+func ParseAge(age string) (time.Duration, error) {
+	age = strings.TrimSpace(age)
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+		"y": 365 * 24 * time.Hour,
+	}
+	if unit, found := units[age[max(len(age)-1, 0):]]; found {
+		count, err := strconv.Atoi(age[:len(age)-1])
+		if err != nil || count <= 0 {
+			return 0, fmt.Errorf("invalid age %q, expected e.g. 30d, 2w or 12h", age)
+		}
+		return time.Duration(count) * unit, nil
+	}
+
+	duration, err := time.ParseDuration(age)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid age %q, expected e.g. 30d, 2w or 12h", age)
+	}
+	return duration, nil
+}
+
+// FormatAge formats the time elapsed since t compactly (e.g., "45m", "3h",
+// "12d", "8w", "2y").
+//
+// Parameters:
+//   - t: The past time
+//
+// Returns:
+//   - string: The compact age
+func FormatAge(t time.Time) string {
+	age := time.Since(t)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	case age < 10*7*24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dw", int(age.Hours()/24/7))
+	}
+	return fmt.Sprintf("%dy", int(age.Hours()/24/365))
+}
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"sort"
+)
+
+// builtinBranchTypes are the branch types gfl knows without configuration,
+// in the order they are listed.
+var builtinBranchTypes = []string{"feature", "fix", "hotfix"}
+
+// BranchTypeConfig describes a branch type in the branchTypes config map.
+// Empty fields fall back to the built-in type of the same name, or to the
+// defaults of a feature branch for new types.
+type BranchTypeConfig struct {
+	// Prefix is the branch name prefix (default: the type name)
+	Prefix string `yaml:"prefix,omitempty"`
+
+	// Base is the branch new branches of this type start from (default: devBaseBranch)
+	Base string `yaml:"base,omitempty"`
+
+	// Aliases are alternative names accepted by 'gfl start <alias>:<name>'
+	Aliases []string `yaml:"aliases,omitempty"`
+
+	// Nickname controls whether the developer nickname is part of the name (default: true)
+	Nickname *bool `yaml:"nickname,omitempty"`
+
+	// CaseFormat overrides branchCaseFormat for this type
+	CaseFormat string `yaml:"caseFormat,omitempty" enum:"original,lower,upper,snake,camel,pascal,kebab"`
+}
+
+// BranchType is a branch type with all settings resolved.
+type BranchType struct {
+	// Name is the type name (e.g., "feature", "chore")
+	Name string
+
+	// Prefix is the branch name prefix (e.g., "feature", "docs")
+	Prefix string
+
+	// Base is the branch new branches of this type start from
+	Base string
+
+	// Aliases are alternative names for the type
+	Aliases []string
+
+	// Nickname reports whether the developer nickname is part of the name
+	Nickname bool
+
+	// CaseFormat is the case format applied to the name part
+	CaseFormat string
+}
+
+// GetBranchTypes returns the built-in branch types followed by the types
+// defined in the branchTypes config, with configured overrides applied.
+//
+// Parameters:
+//   - config: The YAML configuration
+//
+// Returns:
+//   - []BranchType: feature, fix and hotfix first, then custom types sorted by name
+func GetBranchTypes(config *YamlConfig) []BranchType {
+	var types []BranchType
+	for _, name := range builtinBranchTypes {
+		types = append(types, resolveBranchType(config, name))
+	}
+
+	var custom []string
+	for name := range config.BranchTypes {
+		if !isBuiltinBranchType(name) {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+	for _, name := range custom {
+		types = append(types, resolveBranchType(config, name))
+	}
+
+	return types
+}
+
+// GetBranchType looks up a branch type by name or alias.
+// Unknown names resolve to a type that uses the name as prefix and starts
+// from devBaseBranch, so 'gfl start anything:name' keeps working.
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - name: The type name or alias (e.g., "feature", "docs", "doc")
+//
+// Returns:
+//   - BranchType: The resolved branch type
+//   - bool: true if the type is built-in or configured
+func GetBranchType(config *YamlConfig, name string) (BranchType, bool) {
+	for _, branchType := range GetBranchTypes(config) {
+		if branchType.Name == name {
+			return branchType, true
+		}
+		for _, alias := range branchType.Aliases {
+			if alias == name {
+				return branchType, true
+			}
+		}
+	}
+	return resolveBranchType(config, name), false
+}
+
+// resolveBranchType applies the configured settings of a type on top of its
+// built-in defaults.
+func resolveBranchType(config *YamlConfig, name string) BranchType {
+	branchType := BranchType{
+		Name:       name,
+		Prefix:     name,
+		Base:       config.DevBaseBranch,
+		Nickname:   true,
+		CaseFormat: config.BranchCaseFormat,
+	}
+
+	switch name {
+	case "feature":
+		if config.FeaturePrefix != "" {
+			branchType.Prefix = config.FeaturePrefix
+		}
+	case "fix":
+		if config.FixPrefix != "" {
+			branchType.Prefix = config.FixPrefix
+		}
+	case "hotfix":
+		if config.HotfixPrefix != "" {
+			branchType.Prefix = config.HotfixPrefix
+		}
+		branchType.Base = config.ProductionBranch
+	}
+
+	override, ok := config.BranchTypes[name]
+	if !ok {
+		return branchType
+	}
+	if override.Prefix != "" {
+		branchType.Prefix = override.Prefix
+	}
+	if override.Base != "" {
+		branchType.Base = override.Base
+	}
+	if override.Nickname != nil {
+		branchType.Nickname = *override.Nickname
+	}
+	if override.CaseFormat != "" {
+		branchType.CaseFormat = override.CaseFormat
+	}
+	branchType.Aliases = override.Aliases
+
+	return branchType
+}
+
+// isBuiltinBranchType reports whether name is one of the built-in branch types.
+func isBuiltinBranchType(name string) bool {
+	for _, builtin := range builtinBranchTypes {
+		if builtin == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Branch types that are not part of the branch template.
+const (
+	// BranchTypeRelease is the type of release branches created by 'gfl release'
+	BranchTypeRelease = "release"
+
+	// releaseBranchPrefix is the prefix of release branches
+	releaseBranchPrefix = "releases/"
+)
+
+// BranchStatus is a branch as shown by the 'gfl branches' dashboard.
+type BranchStatus struct {
+	// Name is the branch name without the remote (e.g., "feature/aric/login")
+	Name string `json:"name"`
+
+	// Remote is true for origin remote branches
+	Remote bool `json:"remote"`
+
+	// Current is true for the checked out branch
+	Current bool `json:"current"`
+
+	// Type and Nickname are parsed from the branch template, Type is
+	// "release" for release branches and empty for other branches
+	Type     string `json:"type,omitempty"`
+	Nickname string `json:"nickname,omitempty"`
+
+	// Upstream is the upstream of a local branch, UpstreamGone is true when it
+	// was deleted, UpstreamAhead and UpstreamBehind count unpushed and unpulled commits
+	Upstream       string `json:"upstream,omitempty"`
+	UpstreamGone   bool   `json:"upstreamGone,omitempty"`
+	UpstreamAhead  int    `json:"upstreamAhead,omitempty"`
+	UpstreamBehind int    `json:"upstreamBehind,omitempty"`
+
+	// Base is the devBaseBranch ref Ahead and Behind are counted against
+	Base   string `json:"base,omitempty"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+
+	// LastCommit, Author and Subject describe the branch tip
+	LastCommit time.Time `json:"lastCommit"`
+	Author     string    `json:"author"`
+	Subject    string    `json:"subject"`
+
+	// MergedInto is the base branch the branch was merged into, MergeKind and
+	// MergeConfidence tell how it was detected (see GetMergedBranches)
+	MergedInto      string `json:"mergedInto,omitempty"`
+	MergeKind       string `json:"mergeKind,omitempty"`
+	MergeConfidence string `json:"mergeConfidence,omitempty"`
+
+	// InFlight is true for release branches and branches of a type based on
+	// productionBranch (e.g., hotfix) not yet merged into productionBranch
+	InFlight bool `json:"inFlight"`
+}
+
+// GetBranchStatuses collects the dashboard information of every local
+// branch, or every origin remote branch, most recently committed first.
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - remote: true for the origin remote branches, false for local branches
+//
+// Returns:
+//   - []BranchStatus: The branches
+//   - error: Error if the branches cannot be listed
+func GetBranchStatuses(config *YamlConfig, remote bool) ([]BranchStatus, error) {
+	details, err := ListBranchDetails(remote)
+	if err != nil {
+		return nil, err
+	}
+	// Without devBaseBranch and productionBranch nothing is reported as merged
+	merged, _ := GetMergedBranches(config, remote, nil)
+	base := DevBaseRef(config, remote)
+	production := baseRef(config.ProductionBranch, remote)
+	currentBranch, _ := GetCurrentBranch()
+
+	var statuses []BranchStatus
+	inFlight := map[string]int{}
+	for name, detail := range details {
+		status := BranchStatus{
+			Name:           name,
+			Remote:         remote,
+			Current:        !remote && name == currentBranch,
+			Type:           BranchTypeOf(config, name),
+			Upstream:       detail.Upstream,
+			UpstreamGone:   detail.UpstreamGone,
+			UpstreamAhead:  detail.UpstreamAhead,
+			UpstreamBehind: detail.UpstreamBehind,
+			LastCommit:     detail.LastCommit,
+			Author:         detail.AuthorName,
+			Subject:        detail.Subject,
+		}
+		if parts, ok := ParseBranchName(config, name); ok {
+			status.Nickname = parts.Nickname
+		}
+		if base != "" && name != config.DevBaseBranch {
+			if ahead, behind, err := CountAheadBehind(detail.Ref, base); err == nil {
+				status.Base, status.Ahead, status.Behind = base, ahead, behind
+			}
+		}
+		if info, found := merged[name]; found {
+			status.MergedInto, status.MergeKind, status.MergeConfidence = info.Base, info.Kind, info.Confidence
+		}
+		if production != "" && isProductionBound(config, status.Type) {
+			if !IsAncestor(detail.Ref, production) {
+				inFlight[detail.Ref] = len(statuses)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	// Release and hotfix branches may have been rebased or squashed into
+	// productionBranch, their patches are compared in one pass
+	if len(inFlight) > 0 {
+		var refs []string
+		for ref := range inFlight {
+			refs = append(refs, ref)
+		}
+		index := newPatchIndex(production, refs)
+		for ref, i := range inFlight {
+			_, merged := index.detect(ref)
+			statuses[i].InFlight = !merged
+		}
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if !statuses[i].LastCommit.Equal(statuses[j].LastCommit) {
+			return statuses[i].LastCommit.After(statuses[j].LastCommit)
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses, nil
+}
+
+// BranchTypeOf returns the type of a branch: the type parsed from the branch
+// template, "release" for release branches, or empty string.
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - branch: The branch name without remote
+//
+// Returns:
+//   - string: The branch type
+func BranchTypeOf(config *YamlConfig, branch string) string {
+	if strings.HasPrefix(branch, releaseBranchPrefix) {
+		return BranchTypeRelease
+	}
+	if parts, ok := ParseBranchName(config, branch); ok {
+		return parts.Type
+	}
+	return ""
+}
+
+// isProductionBound reports whether branches of a type are meant to be merged
+// into productionBranch: release branches and every type, built-in or custom,
+// whose base is productionBranch (e.g., hotfix).
+func isProductionBound(config *YamlConfig, branchType string) bool {
+	if branchType == BranchTypeRelease {
+		return true
+	}
+	if branchType == "" {
+		return false
+	}
+	resolved, _ := GetBranchType(config, branchType)
+	return resolved.Base == config.ProductionBranch
+}
+
+// isMergedInto reports whether a branch is contained in base, merged or
+// rebased/squashed (see detectPatchMerge).
+func isMergedInto(ref, base string) bool {
+	if exec.Command("git", "merge-base", "--is-ancestor", ref, base).Run() == nil {
+		return true
+	}
+	_, merged := detectPatchMerge(ref, base)
+	return merged
+}
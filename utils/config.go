@@ -1,10 +1,15 @@
 package utils
 
 import (
+	gflstrings "gfl/utils/strings"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"sync"
 
-	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // debugOverride stores the global debug flag value from command line.
@@ -48,63 +53,219 @@ func IsDebugMode() bool {
 	return false
 }
 
+const (
+	// GlobalConfigFile is the shared configuration file committed to the repository
+	GlobalConfigFile = ".gfl.config.yml"
+
+	// LocalConfigFile is the personal configuration file ignored by git
+	LocalConfigFile = ".gfl.config.local.yml"
+)
+
+// UserConfigPath returns the path of the user-wide configuration file,
+// $XDG_CONFIG_HOME/gfl/config.yml or ~/.config/gfl/config.yml.
+//
+// Returns:
+//   - string: The file path, or empty string if no home directory is known
+func UserConfigPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gfl", "config.yml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gfl", "config.yml")
+}
+
+// configDirs caches the directories searched for configuration files,
+// the working directory does not change while a command runs.
+var (
+	configDirsOnce sync.Once
+	configDirs     []string
+)
+
+// configDirectories returns the directories whose configuration files apply
+// to the working directory: the repository root first, then each directory
+// below it down to the working directory. Outside a Git repository only the
+// working directory is returned.
+func configDirectories() []string {
+	configDirsOnce.Do(func() {
+		cwd, err := os.Getwd()
+		if err != nil {
+			configDirs = []string{"."}
+			return
+		}
+		root, err := GetRepositoryRoot()
+		if err != nil {
+			configDirs = []string{cwd}
+			return
+		}
+		// Compare resolved paths, the working directory may be reached through a symlink
+		if resolved, err := filepath.EvalSymlinks(cwd); err == nil {
+			cwd = resolved
+		}
+		if resolved, err := filepath.EvalSymlinks(root); err == nil {
+			root = resolved
+		}
+		rel, err := filepath.Rel(root, cwd)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			configDirs = []string{cwd}
+			return
+		}
+
+		configDirs = []string{root}
+		if rel != "." {
+			dir := root
+			for _, part := range strings.Split(rel, string(filepath.Separator)) {
+				dir = filepath.Join(dir, part)
+				configDirs = append(configDirs, dir)
+			}
+		}
+	})
+	return configDirs
+}
+
+// configFilePath joins a directory and a configuration file name and makes
+// the result relative to the working directory when possible, so messages
+// show ".gfl.config.yml" at the repository root and "../.gfl.config.yml"
+// one level below it.
+func configFilePath(dir, name string) string {
+	path := filepath.Join(dir, name)
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if resolved, err := filepath.EvalSymlinks(cwd); err == nil {
+		cwd = resolved
+	}
+	if rel, err := filepath.Rel(cwd, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// RepoConfigPath returns the path of a configuration file at the repository
+// root (e.g., GlobalConfigFile), so commands behave the same in every
+// subdirectory of the repository.
+//
+// Parameters:
+//   - name: The file name (GlobalConfigFile or LocalConfigFile)
+//
+// Returns:
+//   - string: The file path, relative to the working directory when possible
+func RepoConfigPath(name string) string {
+	return configFilePath(configDirectories()[0], name)
+}
+
+// CustomConfigPath returns the file named by GFL_CONFIG_FILE. Relative paths
+// are resolved against the repository root, not the working directory.
+//
+// Returns:
+//   - string: The file path, or empty string if GFL_CONFIG_FILE is not set
+func CustomConfigPath() string {
+	path := os.Getenv("GFL_CONFIG_FILE")
+	if path == "" {
+		return ""
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return configFilePath(configDirectories()[0], path)
+}
+
 // YamlConfig represents the configuration structure for GFL (GitHub Flow CLI).
 // It defines all available configuration options with their YAML tags for serialization.
+// The struct tags also drive defaults and display, see ConfigField.
 //
 // Configuration priority (highest to lowest):
-//   1. Custom config file (GFL_CONFIG_FILE environment variable)
-//   2. Local config file (.gfl.config.local.yml)
-//   3. Global config file (.gfl.config.yml)
-//   4. Default values
+//   1. Environment variables (GFL_<KEY>)
+//   2. Git config (gfl.<key>, repository before global)
+//   3. Custom config file (GFL_CONFIG_FILE environment variable)
+//   4. Local config file (.gfl.config.local.yml)
+//   5. Global config file (.gfl.config.yml)
+//   6. User config file ($XDG_CONFIG_HOME/gfl/config.yml)
+//   7. Default values
 type YamlConfig struct {
 	// Debug enables verbose logging and debugging output
-	Debug bool `yaml:"debug"`
-
-	// DebugSet indicates whether debug was explicitly set in config
-	DebugSet bool `yaml:"-"`
+	Debug bool `yaml:"debug" default:"false" label:"config.debug_mode"`
 
-	// DevBaseBranch specifies the base branch for feature development (default: "develop")
-	DevBaseBranch string `yaml:"devBaseBranch,omitempty"`
+	// Language sets the interface language when GFL_LANG is not set
+	// Supported values: "zh-CN", "en-US" (default: detected from the system)
+	Language string `yaml:"language,omitempty" label:"config.language" empty:"config.language_auto" enum:"zh-CN,en-US"`
 
-	// DevBaseBranchSet indicates whether devBaseBranch was explicitly set
-	DevBaseBranchSet bool `yaml:"-"`
+	// DevBaseBranch specifies the base branch for feature development (default: "dev")
+	DevBaseBranch string `yaml:"devBaseBranch,omitempty" default:"dev" label:"config.develop_base_branch"`
 
 	// ProductionBranch specifies the main production branch (default: "main")
-	ProductionBranch string `yaml:"productionBranch,omitempty"`
-
-	// ProductionBranchSet indicates whether productionBranch was explicitly set
-	ProductionBranchSet bool `yaml:"-"`
+	ProductionBranch string `yaml:"productionBranch,omitempty" default:"main" label:"config.production_branch"`
 
 	// Nickname is the developer's identifier used in branch naming
-	Nickname string `yaml:"nickname,omitempty"`
-
-	// NicknameSet indicates whether nickname was explicitly set in config
-	NicknameSet bool `yaml:"-"`
+	Nickname string `yaml:"nickname,omitempty" label:"config.nickname"`
 
 	// FeaturePrefix defines the prefix for feature branches (default: "feature")
-	FeaturePrefix string `yaml:"featurePrefix,omitempty"`
-
-	// FeaturePrefixSet indicates whether featurePrefix was explicitly set
-	FeaturePrefixSet bool `yaml:"-"`
+	FeaturePrefix string `yaml:"featurePrefix,omitempty" default:"feature" label:"config.feature_prefix"`
 
 	// FixPrefix defines the prefix for bug fix branches (default: "fix")
-	FixPrefix string `yaml:"fixPrefix,omitempty"`
-
-	// FixPrefixSet indicates whether fixPrefix was explicitly set
-	FixPrefixSet bool `yaml:"-"`
+	FixPrefix string `yaml:"fixPrefix,omitempty" default:"fix" label:"config.fix_prefix"`
 
 	// HotfixPrefix defines the prefix for hotfix branches (default: "hotfix")
-	HotfixPrefix string `yaml:"hotfixPrefix,omitempty"`
-
-	// HotfixPrefixSet indicates whether hotfixPrefix was explicitly set
-	HotfixPrefixSet bool `yaml:"-"`
+	HotfixPrefix string `yaml:"hotfixPrefix,omitempty" default:"hotfix" label:"config.hotfix_prefix"`
 
 	// BranchCaseFormat defines the case format for branch names (default: "original")
 	// Supported values: "lower", "upper", "snake", "camel", "pascal", "kebab", "original"
-	BranchCaseFormat string `yaml:"branchCaseFormat,omitempty"`
+	BranchCaseFormat string `yaml:"branchCaseFormat,omitempty" default:"original" label:"config.branch_case_format" enum:"original,lower,upper,snake,camel,pascal,kebab"`
+
+	// BranchTemplate defines how branch names are built from placeholders
+	// (default: DefaultBranchTemplate)
+	// Supported placeholders: {type}, {nickname}, {name}, {issue}, {date}, {user}
+	BranchTemplate string `yaml:"branchTemplate,omitempty" default:"{type}/{nickname}/{name}" label:"config.branch_template"`
+
+	// BranchTypes defines additional branch types or overrides the built-in
+	// feature, fix and hotfix types (e.g., chore, docs, experiment, support)
+	BranchTypes map[string]BranchTypeConfig `yaml:"branchTypes,omitempty" label:"config.branch_types"`
+
+	// BranchMaxLength limits the length of generated branch names (default: 0, no limit)
+	BranchMaxLength int `yaml:"branchMaxLength,omitempty" default:"0" label:"config.branch_max_length" empty:"config.no_limit"`
+
+	// BranchTransliterate defines how non-ASCII branch names are converted (default: "none")
+	// Supported values: "none", "pinyin", "slug"
+	BranchTransliterate string `yaml:"branchTransliterate,omitempty" default:"none" label:"config.branch_transliterate" enum:"none,pinyin,slug"`
 
-	// BranchCaseFormatSet indicates whether branchCaseFormat was explicitly set
-	BranchCaseFormatSet bool `yaml:"-"`
+	// ProtectedBranches lists glob patterns of branches that sweep, rename,
+	// publish, restore and rebase refuse to act on without --force-protected
+	// (default: productionBranch, devBaseBranch and "releases/*")
+	ProtectedBranches []string `yaml:"protectedBranches,omitempty" label:"config.protected_branches" empty:"config.protected_branches_default"`
+
+	// SweepKeep lists glob patterns of branches that sweep never selects,
+	// unlike ProtectedBranches they cannot be overridden from the command line
+	SweepKeep []string `yaml:"sweepKeep,omitempty" label:"config.sweep_keep"`
+
+	// Autostash controls what checkout, start, copy and rebase do with
+	// uncommitted changes (default: "off", see the Autostash* modes)
+	// Supported values: "off", "carry", "branch"
+	Autostash string `yaml:"autostash,omitempty" default:"off" label:"config.autostash" enum:"off,carry,branch"`
+
+	// IssueTracker configures a custom issue tracker used to look up issues
+	// when starting a branch from an issue reference (default: GitHub via gh)
+	IssueTracker IssueTrackerConfig `yaml:"issueTracker,omitempty" label:"config.issue_tracker" empty:"config.github_issues"`
+}
+
+// IssueTrackerConfig describes how to look up an issue in a custom issue tracker.
+type IssueTrackerConfig struct {
+	// URL is the issue API endpoint, {id} is replaced with the issue id
+	// (e.g., "http://localhost:8080/api/issues/{id}")
+	URL string `yaml:"url,omitempty"`
+
+	// TitleField is the dot-separated JSON path of the issue title (default: "title")
+	TitleField string `yaml:"titleField,omitempty"`
+
+	// LinkField is the dot-separated JSON path of the issue web link (default: the API URL)
+	LinkField string `yaml:"linkField,omitempty"`
 }
 
 // ConfigSource represents a single configuration source with metadata.
@@ -113,13 +274,19 @@ type ConfigSource struct {
 	// Name is a human-readable name for the configuration source
 	Name string
 
-	// Path is the file system path to the configuration file
+	// Path is the file system path to the configuration file, or the git
+	// config scope or environment variable for sources without a file
 	Path string
 
 	// Config contains the parsed configuration from this source
 	Config YamlConfig
 
-	// Exists indicates whether the configuration file actually exists on disk
+	// Values contains the keys exactly as set in this source, used for
+	// layered merging and per-key source attribution
+	Values map[string]interface{}
+
+	// Exists indicates whether the configuration file actually exists on disk,
+	// or for git config and environment variables whether they set any key
 	Exists bool
 }
 
@@ -133,6 +300,80 @@ type ConfigInfo struct {
 	Sources []ConfigSource
 }
 
+// SourceOf returns the name of the source that determines a configuration
+// value, i.e. the highest priority source that sets the key.
+//
+// Parameters:
+//   - path: The dot-separated key (e.g., "nickname" or "issueTracker.url")
+//
+// Returns:
+//   - string: The source name, or empty string if the default value applies
+func (info ConfigInfo) SourceOf(path string) string {
+	source, ok := info.Origin(path)
+	if !ok {
+		return ""
+	}
+	return source.Name
+}
+
+// Origin returns the highest priority source that sets a configuration key.
+//
+// Parameters:
+//   - path: The dot-separated key (e.g., "nickname" or "issueTracker.url")
+//
+// Returns:
+//   - ConfigSource: The source
+//   - bool: false if the default value applies
+func (info ConfigInfo) Origin(path string) (ConfigSource, bool) {
+	for i := len(info.Sources) - 1; i >= 0; i-- {
+		source := info.Sources[i]
+		if !source.Exists {
+			continue
+		}
+		if _, ok := lookupConfigValue(source.Values, path); ok {
+			return source, true
+		}
+	}
+	return ConfigSource{}, false
+}
+
+// Values returns the effective configuration as raw keys, the form used by
+// 'gfl config get' and 'gfl config list'. Empty values are left out.
+//
+// Returns:
+//   - map[string]interface{}: The merged configuration
+func (info ConfigInfo) Values() map[string]interface{} {
+	values := map[string]interface{}{}
+	data, err := yaml.Marshal(info.FinalConfig)
+	if err != nil {
+		return values
+	}
+	_ = yaml.Unmarshal(data, &values)
+	return values
+}
+
+// SourcesOf returns the names of all sources that set a configuration key,
+// from lowest to highest priority. Maps such as branchTypes are merged, so
+// several sources may contribute to one value.
+//
+// Parameters:
+//   - path: The dot-separated key (e.g., "branchTypes")
+//
+// Returns:
+//   - []string: The source names
+func (info ConfigInfo) SourcesOf(path string) []string {
+	var names []string
+	for _, source := range info.Sources {
+		if !source.Exists {
+			continue
+		}
+		if _, ok := lookupConfigValue(source.Values, path); ok {
+			names = append(names, source.Name)
+		}
+	}
+	return names
+}
+
 // ReadConfig reads and merges configuration from all sources.
 // This function maintains backward compatibility by returning only the final config.
 //
@@ -143,175 +384,213 @@ func ReadConfig() *YamlConfig {
 	return &info.FinalConfig
 }
 
+// ConfiguredLanguage reads only the language key, for choosing the interface
+// language before a command runs. Unlike ReadConfig it does not follow extends
+// and stays quiet: unreadable or invalid sources are skipped.
+//
+// Returns:
+//   - string: The configured language, or empty string if none is set
+func ConfiguredLanguage() string {
+	if language := os.Getenv(envConfigPrefix + "LANGUAGE"); language != "" {
+		return language
+	}
+	if output, err := exec.Command("git", "config", "--get", gitConfigSection+".language").Output(); err == nil {
+		if language := strings.TrimSpace(string(output)); language != "" {
+			return language
+		}
+	}
+
+	// Files from the highest priority down
+	dirs := configDirectories()
+	files := []string{CustomConfigPath()}
+	for _, name := range []string{LocalConfigFile, GlobalConfigFile} {
+		for i := len(dirs) - 1; i >= 0; i-- {
+			files = append(files, configFilePath(dirs[i], name))
+		}
+	}
+	files = append(files, UserConfigPath())
+
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var config struct {
+			Language string `yaml:"language"`
+		}
+		if yaml.Unmarshal(data, &config) == nil && config.Language != "" {
+			return config.Language
+		}
+	}
+	return ""
+}
+
 // ReadConfigWithSources reads configuration from all sources and returns detailed information.
 // This function provides visibility into where each configuration value originated from,
 // which is useful for debugging and the 'gfl config' command.
 //
+// The repository files are looked up at the repository root, so commands run
+// from a subdirectory see the same configuration. Subdirectories between the
+// root and the working directory may add their own files (e.g., one per
+// monorepo sub-project), which override the files above them.
+//
 // Configuration sources are loaded in priority order:
 //   1. Default values (lowest priority)
-//   2. Global config file (.gfl.config.yml)
-//   3. Local config file (.gfl.config.local.yml)
-//   4. Custom config file (GFL_CONFIG_FILE environment variable)
+//   2. User config file ($XDG_CONFIG_HOME/gfl/config.yml)
+//   3. Global config files (.gfl.config.yml), from the root down
+//   4. Local config files (.gfl.config.local.yml), from the root down
+//   5. Custom config file (GFL_CONFIG_FILE environment variable)
+//   6. Global git config (git config --global gfl.<key>)
+//   7. Repository git config (git config gfl.<key>)
+//   8. Environment variables (GFL_<KEY>, highest priority)
 //
 // Returns:
 //   - ConfigInfo: Complete configuration information including all sources
 func ReadConfigWithSources() ConfigInfo {
 	var info ConfigInfo
+	dirs := configDirectories()
 
-	// 1. Load default configuration (lowest priority)
-	defaultConfig := YamlConfig{
-		Debug:            false,
-		DevBaseBranch:    "dev",
-		ProductionBranch: "main",
-		FeaturePrefix:    "feature",
-		FixPrefix:        "fix",
-		HotfixPrefix:     "hotfix",
-		BranchCaseFormat: "original",
+	// 0. User-wide configuration file, shared by all repositories
+	userConfigFile := UserConfigPath()
+	if userConfigFile != "" {
+		info.Sources = append(info.Sources, loadConfigSources(gflstrings.GetPath("config.user_config"), userConfigFile)...)
 	}
 
-	// 2. Load global configuration file
-	globalConfigFile := ".gfl.config.yml"
-	globalConfig := loadConfigFile(globalConfigFile)
-	info.Sources = append(info.Sources, ConfigSource{
-		Name:   "Global Config",
-		Path:   globalConfigFile,
-		Config: globalConfig,
-		Exists: fileExists(globalConfigFile),
-	})
+	// 1. Global configuration files, subdirectory files override the root one
+	globalConfigFile := configFilePath(dirs[0], GlobalConfigFile)
+	info.Sources = append(info.Sources, loadConfigSources(gflstrings.GetPath("config.global_config"), globalConfigFile)...)
+	info.Sources = append(info.Sources, loadDirectoryConfigSources(dirs[1:], GlobalConfigFile)...)
 
-	// 3. Load local configuration file
-	localConfigFile := ".gfl.config.local.yml"
-	localConfig := loadConfigFile(localConfigFile)
-	info.Sources = append(info.Sources, ConfigSource{
-		Name:   "Local Config",
-		Path:   localConfigFile,
-		Config: localConfig,
-		Exists: fileExists(localConfigFile),
-	})
+	// 2. Local configuration files, subdirectory files override the root one
+	localConfigFile := configFilePath(dirs[0], LocalConfigFile)
+	info.Sources = append(info.Sources, loadConfigSources(gflstrings.GetPath("config.local_config"), localConfigFile)...)
+	info.Sources = append(info.Sources, loadDirectoryConfigSources(dirs[1:], LocalConfigFile)...)
 
-	// 4. Load custom configuration file from environment variable
-	var customConfig YamlConfig
-	customConfigFile := os.Getenv("GFL_CONFIG_FILE")
-	if customConfigFile != "" &&
-	   customConfigFile != globalConfigFile &&
-	   customConfigFile != localConfigFile {
-		customConfig = loadConfigFile(customConfigFile)
-		info.Sources = append(info.Sources, ConfigSource{
-			Name:   "Custom Config",
-			Path:   customConfigFile,
-			Config: customConfig,
-			Exists: fileExists(customConfigFile),
-		})
+	// 3. Custom configuration file from environment variable
+	customConfigFile := CustomConfigPath()
+	if customConfigFile != "" && !info.hasSource(customConfigFile) {
+		info.Sources = append(info.Sources, loadConfigSources(gflstrings.GetPath("config.custom_config"), customConfigFile)...)
 	}
 
-	// 5. Merge configurations in priority order:
-	// Default -> Global -> Local -> Custom
-	info.FinalConfig = defaultConfig
-	mergeConfig(&info.FinalConfig, globalConfig)
-	mergeConfig(&info.FinalConfig, localConfig)
-	mergeConfig(&info.FinalConfig, customConfig)
+	// 4. git config gfl.<key>, global before repository
+	info.Sources = append(info.Sources, loadGitConfigSources()...)
+
+	// 5. GFL_<KEY> environment variables
+	info.Sources = append(info.Sources, loadEnvConfigSources()...)
+
+	// 6. Merge configurations in priority order:
+	// Default -> User -> Global -> Local -> Custom -> Git global -> Git repo -> Env
+	merged := defaultConfigValues()
+	for _, source := range info.Sources {
+		mergeConfigValues(merged, source.Values)
+	}
+
+	finalConfig, err := decodeConfigValues(merged)
+	if err != nil {
+		Errorf("Error parsing config: %v", err)
+	}
+	info.FinalConfig = finalConfig
 
 	return info
 }
 
-// loadConfigFile loads and parses a YAML configuration file.
-// It returns an empty config if the file doesn't exist or on parsing errors.
+// loadDirectoryConfigSources loads the configuration files named name that
+// exist in the given subdirectories.
+func loadDirectoryConfigSources(dirs []string, name string) []ConfigSource {
+	var sources []ConfigSource
+	for _, dir := range dirs {
+		path := configFilePath(dir, name)
+		if fileExists(path) {
+			sources = append(sources, loadConfigSources(gflstrings.GetPath("config.directory_config"), path)...)
+		}
+	}
+	return sources
+}
+
+// hasSource reports whether a file is already loaded as a source.
+func (info ConfigInfo) hasSource(path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, source := range info.Sources {
+		if sourceAbs, err := filepath.Abs(source.Path); err == nil && sourceAbs == abs {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadConfigFile reads the keys set in a single configuration file.
 //
 // Parameters:
 //   - filename: Path to the configuration file
 //
 // Returns:
-//   - YamlConfig: Parsed configuration or empty config on error
-func loadConfigFile(filename string) YamlConfig {
+//   - map[string]interface{}: The keys set in the file, empty if it does not exist
+func LoadConfigFile(filename string) map[string]interface{} {
+	return loadConfigFile(filename)
+}
+
+// loadConfigFile loads and parses a YAML configuration file into its raw keys.
+// It returns an empty layer if the file doesn't exist or on parsing errors.
+//
+// Parameters:
+//   - filename: Path to the configuration file
+//
+// Returns:
+//   - map[string]interface{}: The keys set in the file
+func loadConfigFile(filename string) map[string]interface{} {
+	values := map[string]interface{}{}
 	if !fileExists(filename) {
-		return YamlConfig{}
+		return values
 	}
 
-	v := viper.New()
-	v.SetConfigFile(filename)
-
-	if err := v.ReadInConfig(); err != nil {
+	data, err := os.ReadFile(filename)
+	if err != nil {
 		Errorf("Error reading config file %s: %v", filename, err)
-		return YamlConfig{}
-	}
-
-	var config YamlConfig
-	if err := v.Unmarshal(&config); err != nil {
-		Errorf("Error parsing config file %s: %v", filename, err)
-		return YamlConfig{}
-	}
-
-	// Check if fields were explicitly set in the config file
-	// This includes cases where fields are set to empty string or false
-	if v.IsSet("debug") {
-		config.DebugSet = true
-	}
-	if v.IsSet("devBaseBranch") {
-		config.DevBaseBranchSet = true
-	}
-	if v.IsSet("productionBranch") {
-		config.ProductionBranchSet = true
-	}
-	if v.IsSet("nickname") {
-		config.NicknameSet = true
-	}
-	if v.IsSet("featurePrefix") {
-		config.FeaturePrefixSet = true
+		return values
 	}
-	if v.IsSet("fixPrefix") {
-		config.FixPrefixSet = true
-	}
-	if v.IsSet("hotfixPrefix") {
-		config.HotfixPrefixSet = true
-	}
-	if v.IsSet("branchCaseFormat") {
-		config.BranchCaseFormatSet = true
-	}
-
-	return config
+	return parseConfigData(filename, data)
 }
 
-// mergeConfig merges override configuration into base configuration.
-// Only non-empty values from override are applied to base, preserving
-// existing values for fields that are not explicitly set.
+// parseConfigData parses the content of a configuration file into its raw
+// keys. It returns an empty layer on parsing errors.
 //
 // Parameters:
-//   - base: Pointer to the base configuration to be modified
-//   - override: Configuration containing values to override
-func mergeConfig(base *YamlConfig, override YamlConfig) {
-	// Only override fields if they were explicitly set (including empty string or false)
-	if override.DebugSet {
-		base.Debug = override.Debug
-		base.DebugSet = true
-	}
-	if override.DevBaseBranchSet {
-		base.DevBaseBranch = override.DevBaseBranch
-		base.DevBaseBranchSet = true
-	}
-	if override.ProductionBranchSet {
-		base.ProductionBranch = override.ProductionBranch
-		base.ProductionBranchSet = true
-	}
-	if override.NicknameSet {
-		base.Nickname = override.Nickname
-		base.NicknameSet = true
-	}
-	if override.FeaturePrefixSet {
-		base.FeaturePrefix = override.FeaturePrefix
-		base.FeaturePrefixSet = true
+//   - filename: The file name used in error messages
+//   - data: The YAML content
+//
+// Returns:
+//   - map[string]interface{}: The keys set in the file
+func parseConfigData(filename string, data []byte) map[string]interface{} {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		Errorf("Error reading config file %s: %v", filename, err)
+		return map[string]interface{}{}
 	}
-	if override.FixPrefixSet {
-		base.FixPrefix = override.FixPrefix
-		base.FixPrefixSet = true
+	if values == nil {
+		// Empty file or only comments
+		return map[string]interface{}{}
 	}
-	if override.HotfixPrefixSet {
-		base.HotfixPrefix = override.HotfixPrefix
-		base.HotfixPrefixSet = true
+	canonicalizeConfigKeys(values, reflect.TypeOf(YamlConfig{}))
+
+	// Check the types here so one broken file does not break the others;
+	// the file itself is decoded first to report the right line numbers
+	var check YamlConfig
+	if err := yaml.Unmarshal(data, &check); err != nil {
+		Errorf("Error parsing config file %s: %v", filename, err)
+		return map[string]interface{}{}
 	}
-	if override.BranchCaseFormatSet {
-		base.BranchCaseFormat = override.BranchCaseFormat
-		base.BranchCaseFormatSet = true
+	if _, err := decodeConfigValues(values); err != nil {
+		Errorf("Error parsing config file %s: %v", filename, err)
+		return map[string]interface{}{}
 	}
+
+	return values
 }
 
 // fileExists checks if a file exists at the specified path.
@@ -325,4 +604,3 @@ func fileExists(filename string) bool {
 	_, err := os.Stat(filename)
 	return err == nil
 }
-
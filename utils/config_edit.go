@@ -0,0 +1,376 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration scopes accepted by 'gfl config get/set/unset/list'.
+const (
+	ScopeGlobal = "global"
+	ScopeLocal  = "local"
+	ScopeUser   = "user"
+	ScopeFile   = "file"
+)
+
+// ConfigScopePath returns the configuration file that belongs to a scope.
+//
+// Parameters:
+//   - scope: One of ScopeGlobal, ScopeLocal, ScopeUser or ScopeFile
+//
+// Returns:
+//   - string: The file path
+//   - error: Error if the scope has no file (e.g., GFL_CONFIG_FILE is not set)
+func ConfigScopePath(scope string) (string, error) {
+	switch scope {
+	case ScopeGlobal:
+		return RepoConfigPath(GlobalConfigFile), nil
+	case ScopeLocal:
+		return RepoConfigPath(LocalConfigFile), nil
+	case ScopeUser:
+		if path := UserConfigPath(); path != "" {
+			return path, nil
+		}
+		return "", fmt.Errorf("cannot determine the user config directory")
+	case ScopeFile:
+		if path := CustomConfigPath(); path != "" {
+			return path, nil
+		}
+		return "", fmt.Errorf("GFL_CONFIG_FILE is not set")
+	default:
+		return "", fmt.Errorf("unknown config scope %q", scope)
+	}
+}
+
+// ConfigKeyType resolves the Go type and allowed values of a dot-separated
+// configuration key (e.g., "issueTracker.url" or "branchTypes.docs.prefix").
+//
+// Parameters:
+//   - key: The configuration key
+//
+// Returns:
+//   - reflect.Type: The type of the value stored under the key
+//   - []string: The allowed values from the enum tag, empty if unrestricted
+//   - error: Error if the key is unknown
+func ConfigKeyType(key string) (reflect.Type, []string, error) {
+	t := reflect.TypeOf(YamlConfig{})
+	var enum []string
+	for _, part := range strings.Split(key, ".") {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			sf, ok := structFieldByKey(t, part)
+			if !ok {
+				var known []string
+				for i := 0; i < t.NumField(); i++ {
+					if name := yamlKey(t.Field(i)); name != "" {
+						known = append(known, name)
+					}
+				}
+				if suggestion := suggestConfigKey(part, known); suggestion != "" {
+					return nil, nil, fmt.Errorf("unknown config key %q, did you mean %q?", key, suggestion)
+				}
+				return nil, nil, fmt.Errorf("unknown config key %q", key)
+			}
+			t = sf.Type
+			enum = nil
+			if tag := sf.Tag.Get("enum"); tag != "" {
+				enum = strings.Split(tag, ",")
+			}
+		case reflect.Map:
+			t = t.Elem()
+			enum = nil
+		default:
+			return nil, nil, fmt.Errorf("unknown config key %q", key)
+		}
+	}
+	return t, enum, nil
+}
+
+// structFieldByKey finds the struct field serialized under a YAML key.
+func structFieldByKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if yamlKey(t.Field(i)) == key {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// ParseConfigValue converts a command line value into a YAML node of the
+// key's type and validates it. Lists, maps and structs are given in YAML
+// flow syntax (e.g., "[doc, docs]" or "{prefix: doc}").
+//
+// Parameters:
+//   - key: The configuration key
+//   - value: The value as typed by the user
+//
+// Returns:
+//   - *yaml.Node: The node to store in the file
+//   - error: Error if the key is unknown or the value is invalid
+func ParseConfigValue(key, value string) (*yaml.Node, error) {
+	t, enum, err := ConfigKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if len(enum) > 0 && !containsString(enum, value) {
+		return nil, fmt.Errorf("invalid value %q for %s, expected one of: %s", value, key, strings.Join(enum, ", "))
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s, expected true or false", value, key)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(parsed)}, nil
+	case reflect.Int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid value %q for %s, expected a non-negative number", value, key)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(parsed)}, nil
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &document); err != nil || len(document.Content) == 0 {
+		return nil, fmt.Errorf("invalid value %q for %s, expected YAML such as [a, b] or {key: value}", value, key)
+	}
+	node := document.Content[0]
+	if err := node.Decode(reflect.New(t).Interface()); err != nil {
+		return nil, fmt.Errorf("invalid value %q for %s: %v", value, key, err)
+	}
+	return node, nil
+}
+
+// LookupConfigValue reads a dot-separated key from a raw configuration layer
+// such as ConfigInfo.Values or ConfigSource.Values.
+//
+// Returns:
+//   - interface{}: The value
+//   - bool: false if the layer does not contain the key
+func LookupConfigValue(values map[string]interface{}, key string) (interface{}, bool) {
+	return lookupConfigValue(values, key)
+}
+
+// GetConfigFileValue reads a single key from one configuration file.
+//
+// Parameters:
+//   - filename: The configuration file
+//   - key: The dot-separated configuration key
+//
+// Returns:
+//   - interface{}: The value
+//   - bool: false if the file does not set the key
+func GetConfigFileValue(filename, key string) (interface{}, bool) {
+	return lookupConfigValue(loadConfigFile(filename), key)
+}
+
+// SetConfigFileValue writes a single key to a configuration file, keeping
+// comments and the order of the other keys. Missing parent mappings and the
+// file itself are created.
+//
+// Parameters:
+//   - filename: The configuration file
+//   - key: The dot-separated configuration key (e.g., "branchTypes.docs.prefix")
+//   - value: The value node, see ParseConfigValue
+//
+// Returns:
+//   - error: Error if the file cannot be read, parsed or written
+func SetConfigFileValue(filename, key string, value *yaml.Node) error {
+	document, err := readConfigDocument(filename)
+	if err != nil {
+		return err
+	}
+
+	mapping := document.Content[0]
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child := mappingValue(mapping, part)
+		if child == nil || child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(mapping, part, child)
+		}
+		mapping = child
+	}
+	setMappingValue(mapping, parts[len(parts)-1], value)
+
+	return writeConfigDocument(filename, document)
+}
+
+// UnsetConfigFileValue removes a single key from a configuration file,
+// keeping comments and the order of the other keys.
+//
+// Parameters:
+//   - filename: The configuration file
+//   - key: The dot-separated configuration key
+//
+// Returns:
+//   - bool: false if the file did not set the key
+//   - error: Error if the file cannot be read, parsed or written
+func UnsetConfigFileValue(filename, key string) (bool, error) {
+	if !fileExists(filename) {
+		return false, nil
+	}
+	document, err := readConfigDocument(filename)
+	if err != nil {
+		return false, err
+	}
+
+	mapping := document.Content[0]
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		mapping = mappingValue(mapping, part)
+		if mapping == nil || mapping.Kind != yaml.MappingNode {
+			return false, nil
+		}
+	}
+
+	last := parts[len(parts)-1]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, last) {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return true, writeConfigDocument(filename, document)
+		}
+	}
+	return false, nil
+}
+
+// FlattenConfigValues lists the keys of a raw configuration layer as
+// dot-separated paths with their rendered values, sorted by key.
+//
+// Parameters:
+//   - values: The configuration layer
+//
+// Returns:
+//   - []string: Entries in "key=value" form
+func FlattenConfigValues(values map[string]interface{}) []string {
+	var entries []string
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		if object, ok := value.(map[string]interface{}); ok && len(object) > 0 {
+			for key, child := range object {
+				walk(prefix+"."+key, child)
+			}
+			return
+		}
+		rendered := FormatConfigValue(value)
+		if list, ok := value.([]interface{}); ok {
+			items := make([]string, len(list))
+			for i, item := range list {
+				items[i] = fmt.Sprintf("%v", item)
+			}
+			rendered = "[" + strings.Join(items, ", ") + "]"
+		}
+		entries = append(entries, fmt.Sprintf("%s=%s", prefix, rendered))
+	}
+	for key, value := range values {
+		walk(key, value)
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// readConfigDocument parses a configuration file into a YAML document node.
+// A missing or empty file yields a document with an empty mapping.
+func readConfigDocument(filename string) (*yaml.Node, error) {
+	document := &yaml.Node{Kind: yaml.DocumentNode}
+	if fileExists(filename) {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		if err := yaml.Unmarshal(data, document); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+	}
+
+	if len(document.Content) == 0 {
+		document.Kind = yaml.DocumentNode
+		document.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s is not a YAML mapping", filename)
+	}
+	return document, nil
+}
+
+// writeConfigDocument writes a YAML document node back to a file.
+func writeConfigDocument(filename string, document *yaml.Node) error {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filename, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filename, err)
+	}
+
+	if dir := filepath.Dir(filename); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(filename, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return nil
+}
+
+// mappingValue returns the value node of a key in a mapping node. Keys are
+// matched case-insensitively like in loadConfigFile.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of a key in a mapping node, or appends
+// the key when it is not present yet. Comments on the old value are kept.
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			mapping.Content[i].Value = key
+			old := mapping.Content[i+1]
+			value.LineComment = old.LineComment
+			value.HeadComment = old.HeadComment
+			value.FootComment = old.FootComment
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
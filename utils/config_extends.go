@@ -0,0 +1,239 @@
+package utils
+
+import (
+	"fmt"
+	gflstrings "gfl/utils/strings"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// extendsKey is the configuration key that pulls in other configuration
+// files. It takes one reference or a list of them:
+//
+//	extends: ../shared/gfl.yml                 a local file, relative to this file
+//	extends: ~/dotfiles/gfl/base.yml           a file in the home directory
+//	extends: origin/main:.gfl/base.yml         a file at a git ref
+const extendsKey = "extends"
+
+// configRef identifies a configuration file on disk or at a git ref.
+type configRef struct {
+	// ref is the git ref, empty for files on disk
+	ref string
+
+	// path is the file path, relative to the repository root for git refs
+	path string
+}
+
+// String formats the reference as shown in 'gfl config' ("path" or "ref:path").
+func (r configRef) String() string {
+	if r.ref == "" {
+		return r.path
+	}
+	return r.ref + ":" + r.path
+}
+
+// id identifies the file for cycle detection.
+func (r configRef) id() string {
+	if r.ref != "" {
+		return r.String()
+	}
+	if abs, err := filepath.Abs(r.path); err == nil {
+		return abs
+	}
+	return r.path
+}
+
+// gitConfigFiles caches files read from git refs and extendsIssues the
+// errors already shown, the configuration is read several times by one
+// command.
+var (
+	gitConfigFiles sync.Map
+
+	extendsIssuesMutex sync.Mutex
+	extendsIssues      []ConfigIssue
+)
+
+// loadConfigSources loads a configuration file as a named source, preceded by
+// the files it extends (recursively), so the extending file wins the merge.
+//
+// Parameters:
+//   - name: The source name (e.g., "Global Config")
+//   - filename: Path to the configuration file
+//
+// Returns:
+//   - []ConfigSource: The inherited sources followed by the file itself
+func loadConfigSources(name, filename string) []ConfigSource {
+	return loadExtendedSources(name, configRef{path: filename}, nil)
+}
+
+// loadExtendedSources loads a configuration file and the files it extends.
+// chain lists the files that extend this one, to detect cycles.
+func loadExtendedSources(name string, ref configRef, chain []configRef) []ConfigSource {
+	values, exists := readConfigRef(ref)
+	targets := extendsTargets(ref, values[extendsKey])
+	delete(values, extendsKey)
+
+	chain = append(chain, ref)
+	var sources []ConfigSource
+	for _, target := range targets {
+		parent, err := resolveExtends(ref, target)
+		if err != nil {
+			reportExtendsError(ref, gflstrings.GetPath("config.extends_failed", target, err))
+			continue
+		}
+		if cycle := extendsCycle(chain, parent); cycle != "" {
+			reportExtendsError(ref, gflstrings.GetPath("config.extends_cycle", cycle))
+			continue
+		}
+		sources = append(sources, loadExtendedSources(gflstrings.GetPath("config.inherited_config"), parent, chain)...)
+	}
+
+	config, _ := decodeConfigValues(values)
+	return append(sources, ConfigSource{
+		Name:   name,
+		Path:   ref.String(),
+		Config: config,
+		Values: values,
+		Exists: exists,
+	})
+}
+
+// readConfigRef reads the raw keys of a file on disk or at a git ref.
+//
+// Returns:
+//   - map[string]interface{}: The keys set in the file
+//   - bool: false if the file does not exist
+func readConfigRef(ref configRef) (map[string]interface{}, bool) {
+	if ref.ref == "" {
+		return loadConfigFile(ref.path), fileExists(ref.path)
+	}
+
+	data, ok := gitConfigFiles.Load(ref.String())
+	if !ok {
+		output, err := exec.Command("git", "show", ref.String()).Output()
+		if err != nil {
+			reportExtendsError(ref, gflstrings.GetPath("config.extends_failed", ref.String(), gitErrorMessage(err)))
+			output = nil
+		}
+		data, _ = gitConfigFiles.LoadOrStore(ref.String(), output)
+	}
+	if data.([]byte) == nil {
+		return map[string]interface{}{}, false
+	}
+	return parseConfigData(ref.String(), data.([]byte)), true
+}
+
+// extendsTargets reads the extends key, which holds one reference or a list.
+func extendsTargets(ref configRef, value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []interface{}:
+		var targets []string
+		for _, item := range v {
+			if target, ok := item.(string); ok {
+				targets = append(targets, target)
+			} else {
+				reportExtendsError(ref, gflstrings.GetPath("config.extends_failed", fmt.Sprint(item), fmt.Errorf("%s: expected a string", ref)))
+			}
+		}
+		return targets
+	default:
+		reportExtendsError(ref, gflstrings.GetPath("config.extends_failed", fmt.Sprint(v), fmt.Errorf("%s: expected a string or a list", ref)))
+		return nil
+	}
+}
+
+// resolveExtends resolves a reference found in the file from. Relative paths
+// are resolved against the directory of the extending file; inside a git ref
+// they stay in the same ref. "ref:path" reads the file at a git ref unless a
+// file of that name exists on disk.
+func resolveExtends(from configRef, target string) (configRef, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return configRef{}, fmt.Errorf("empty reference")
+	}
+
+	local := target
+	switch {
+	case strings.HasPrefix(target, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return configRef{}, err
+		}
+		local = filepath.Join(home, target[2:])
+	case filepath.IsAbs(target):
+	case from.ref != "" && !strings.Contains(target, ":"):
+		return configRef{ref: from.ref, path: path.Join(path.Dir(from.path), target)}, nil
+	case from.ref == "":
+		local = filepath.Join(filepath.Dir(from.path), target)
+	}
+
+	if ref, file, ok := strings.Cut(target, ":"); ok && !fileExists(local) {
+		if ref == "" || file == "" {
+			return configRef{}, fmt.Errorf("expected ref:path")
+		}
+		return configRef{ref: ref, path: strings.TrimPrefix(file, "/")}, nil
+	}
+	if !fileExists(local) {
+		return configRef{}, fmt.Errorf("no such file: %s", local)
+	}
+	return configRef{path: local}, nil
+}
+
+// extendsCycle returns the cycle (e.g., "a.yml -> b.yml -> a.yml") if ref is
+// already in the chain of extending files, or empty string.
+func extendsCycle(chain []configRef, ref configRef) string {
+	for i, link := range chain {
+		if link.id() != ref.id() {
+			continue
+		}
+		var names []string
+		for _, item := range chain[i:] {
+			names = append(names, item.String())
+		}
+		return strings.Join(append(names, ref.String()), " -> ")
+	}
+	return ""
+}
+
+// reportExtendsError shows an extends error once per command and keeps it
+// for ExtendsIssues.
+func reportExtendsError(ref configRef, message string) {
+	extendsIssuesMutex.Lock()
+	defer extendsIssuesMutex.Unlock()
+	issue := ConfigIssue{File: ref.String(), Key: extendsKey, Message: message}
+	for _, reported := range extendsIssues {
+		if reported == issue {
+			return
+		}
+	}
+	extendsIssues = append(extendsIssues, issue)
+	Error(message)
+}
+
+// ExtendsIssues returns the extends errors found while reading the
+// configuration (unreadable files, cycles), so 'gfl config validate' can
+// report them with the other problems.
+//
+// Returns:
+//   - []ConfigIssue: The errors in the order they were found
+func ExtendsIssues() []ConfigIssue {
+	extendsIssuesMutex.Lock()
+	defer extendsIssuesMutex.Unlock()
+	return append([]ConfigIssue(nil), extendsIssues...)
+}
+
+// gitErrorMessage returns the message git printed for a failed command.
+func gitErrorMessage(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+package utils
+
+import (
+	gflstrings "gfl/utils/strings"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ettle/strcase"
+)
+
+const (
+	// envConfigPrefix is the prefix of environment variables that override
+	// configuration keys (e.g., GFL_DEV_BASE_BRANCH)
+	envConfigPrefix = "GFL_"
+
+	// gitConfigSection is the git config section that overrides configuration
+	// keys (e.g., git config gfl.devBaseBranch develop)
+	gitConfigSection = "gfl"
+)
+
+// gitConfigEntry is a gfl.* entry read from git config.
+type gitConfigEntry struct {
+	scope string
+	key   string
+	value string
+}
+
+// gitConfigEntries caches the gfl.* entries of git config, they do not
+// change while a command runs.
+var (
+	gitConfigOnce  sync.Once
+	gitConfigCache []gitConfigEntry
+)
+
+// reportedOverrides remembers the invalid overrides already reported, the
+// configuration is read several times by one command.
+var reportedOverrides sync.Map
+
+// ConfigEnvName returns the environment variable that overrides a
+// configuration key (e.g., "devBaseBranch" -> "GFL_DEV_BASE_BRANCH",
+// "issueTracker.url" -> "GFL_ISSUE_TRACKER_URL").
+//
+// Parameters:
+//   - key: The dot-separated configuration key
+//
+// Returns:
+//   - string: The environment variable name
+func ConfigEnvName(key string) string {
+	var parts []string
+	for _, part := range strings.Split(key, ".") {
+		parts = append(parts, strcase.ToSNAKE(part))
+	}
+	return envConfigPrefix + strings.Join(parts, "_")
+}
+
+// configEnvKeys maps every supported environment variable to its key: one
+// per top-level key, plus one per field of struct keys such as issueTracker.
+// Maps such as branchTypes are set as a whole in YAML flow syntax.
+func configEnvKeys() map[string]string {
+	keys := map[string]string{}
+	for _, field := range ConfigFields() {
+		keys[ConfigEnvName(field.Key)] = field.Key
+		if field.Type.Kind() != reflect.Struct {
+			continue
+		}
+		for i := 0; i < field.Type.NumField(); i++ {
+			if name := yamlKey(field.Type.Field(i)); name != "" {
+				key := field.Key + "." + name
+				keys[ConfigEnvName(key)] = key
+			}
+		}
+	}
+	return keys
+}
+
+// loadEnvConfigSources reads the GFL_<KEY> environment variables, one source
+// per variable so 'gfl config' can name the variable a value came from.
+// Invalid values are reported and ignored.
+func loadEnvConfigSources() []ConfigSource {
+	envKeys := configEnvKeys()
+	var names []string
+	for name := range envKeys {
+		if _, ok := os.LookupEnv(name); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var sources []ConfigSource
+	for _, name := range names {
+		values := map[string]interface{}{}
+		if err := setConfigOverride(values, envKeys[name], os.Getenv(name)); err != nil {
+			warnInvalidOverride(name, err)
+			continue
+		}
+		sources = append(sources, newOverrideSource(gflstrings.GetPath("config.env_config"), name, values))
+	}
+	return sources
+}
+
+// loadGitConfigSources reads the gfl.* keys from git config as two sources:
+// the user's global (and system) git config, then the repository's.
+// Invalid values are reported and ignored.
+func loadGitConfigSources() []ConfigSource {
+	globalValues := map[string]interface{}{}
+	repoValues := map[string]interface{}{}
+
+	for _, entry := range readGitConfigEntries() {
+		values := repoValues
+		if entry.scope == "global" || entry.scope == "system" {
+			values = globalValues
+		}
+		key := canonicalConfigKey(strings.TrimPrefix(entry.key, gitConfigSection+"."))
+		if err := setConfigOverride(values, key, entry.value); err != nil {
+			warnInvalidOverride(entry.key, err)
+		}
+	}
+
+	return []ConfigSource{
+		newOverrideSource(gflstrings.GetPath("config.git_global_config"), "git config --global", globalValues),
+		newOverrideSource(gflstrings.GetPath("config.git_repo_config"), "git config --local", repoValues),
+	}
+}
+
+// readGitConfigEntries lists the gfl.* entries of git config with their scope.
+func readGitConfigEntries() []gitConfigEntry {
+	gitConfigOnce.Do(func() {
+		output, err := exec.Command("git", "config", "-z", "--show-scope", "--get-regexp", `^`+gitConfigSection+`\.`).Output()
+		if err != nil {
+			// No matching keys, not in a repository or git is too old
+			return
+		}
+
+		// With -z every entry is "<scope>\0<key>\n<value>\0"
+		fields := strings.Split(string(output), "\x00")
+		for i := 0; i+1 < len(fields); i += 2 {
+			key, value, hasValue := strings.Cut(fields[i+1], "\n")
+			if !hasValue {
+				// "[gfl] debug" without a value means true
+				value = "true"
+			}
+			gitConfigCache = append(gitConfigCache, gitConfigEntry{scope: fields[i], key: key, value: value})
+		}
+	})
+	return gitConfigCache
+}
+
+// setConfigOverride parses a value given as text and stores it in a raw
+// configuration layer under its dot-separated key.
+func setConfigOverride(values map[string]interface{}, key, value string) error {
+	node, err := ParseConfigValue(key, value)
+	if err != nil {
+		return err
+	}
+	var decoded interface{}
+	if err := node.Decode(&decoded); err != nil {
+		return err
+	}
+
+	parts := strings.Split(key, ".")
+	current := values
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = decoded
+	return nil
+}
+
+// warnInvalidOverride reports an ignored override once per command.
+func warnInvalidOverride(name string, err error) {
+	if _, reported := reportedOverrides.LoadOrStore(name, true); !reported {
+		Warning(gflstrings.GetPath("config.invalid_override", name, err))
+	}
+}
+
+// newOverrideSource builds a source that is not backed by a file.
+func newOverrideSource(name, path string, values map[string]interface{}) ConfigSource {
+	config, err := decodeConfigValues(values)
+	if err != nil {
+		Errorf("Error parsing config from %s: %v", path, err)
+	}
+	return ConfigSource{
+		Name:   name,
+		Path:   path,
+		Config: config,
+		Values: values,
+		Exists: len(values) > 0,
+	}
+}
+
+// canonicalConfigKey fixes the case of a dot-separated key (git lowercases
+// variable names, e.g., "devbasebranch" -> "devBaseBranch"). Map keys such
+// as branch type names are kept as given.
+func canonicalConfigKey(key string) string {
+	t := reflect.TypeOf(YamlConfig{})
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			sf, ok := structFieldByKeyFold(t, part)
+			if !ok {
+				return key
+			}
+			parts[i] = yamlKey(sf)
+			t = sf.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return key
+		}
+	}
+	return strings.Join(parts, ".")
+}
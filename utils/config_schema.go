@@ -0,0 +1,272 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigField describes a top-level configuration key. Fields are derived
+// from the struct tags of YamlConfig, so adding an option only needs a new
+// struct field:
+//
+//	yaml:"key"        the key in the config files
+//	default:"value"   the default value (strings, bools and ints)
+//	label:"path"      the strings path of the label shown by 'gfl config'
+//	empty:"path"      the strings path shown instead of an empty value
+//	enum:"a,b,c"      the allowed values
+type ConfigField struct {
+	// Key is the YAML key (e.g., "devBaseBranch")
+	Key string
+
+	// Label is the strings path of the display label (e.g., "config.develop_base_branch")
+	Label string
+
+	// Default is the raw default value from the struct tag
+	Default string
+
+	// Empty is the strings path of the text shown when the value is empty
+	Empty string
+
+	// Enum lists the allowed values, empty when any value is accepted
+	Enum []string
+
+	// Type is the Go type of the field
+	Type reflect.Type
+
+	index int
+}
+
+// configFields caches the registry built from YamlConfig.
+var configFields = buildConfigFields()
+
+// ConfigFields returns every configuration key in declaration order.
+//
+// Returns:
+//   - []ConfigField: The registered configuration fields
+func ConfigFields() []ConfigField {
+	return configFields
+}
+
+// LookupConfigField finds a configuration field by its YAML key.
+//
+// Parameters:
+//   - key: The YAML key (e.g., "branchCaseFormat")
+//
+// Returns:
+//   - ConfigField: The field
+//   - bool: false if no such key exists
+func LookupConfigField(key string) (ConfigField, bool) {
+	for _, field := range configFields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return ConfigField{}, false
+}
+
+// Value returns the field's value in a configuration.
+func (f ConfigField) Value(config *YamlConfig) interface{} {
+	return reflect.ValueOf(config).Elem().Field(f.index).Interface()
+}
+
+// buildConfigFields reads the struct tags of YamlConfig.
+func buildConfigFields() []ConfigField {
+	var fields []ConfigField
+	t := reflect.TypeOf(YamlConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := yamlKey(sf)
+		if key == "" {
+			continue
+		}
+
+		field := ConfigField{
+			Key:     key,
+			Label:   sf.Tag.Get("label"),
+			Default: sf.Tag.Get("default"),
+			Empty:   sf.Tag.Get("empty"),
+			Type:    sf.Type,
+			index:   i,
+		}
+		if enum := sf.Tag.Get("enum"); enum != "" {
+			field.Enum = strings.Split(enum, ",")
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// yamlKey returns the YAML key of a struct field, or "" if it is not serialized.
+func yamlKey(sf reflect.StructField) string {
+	name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return sf.Name
+	}
+	return name
+}
+
+// defaultConfigValues builds the default layer from the default struct tags.
+func defaultConfigValues() map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, field := range configFields {
+		if field.Default == "" {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Bool:
+			value, err := strconv.ParseBool(field.Default)
+			if err == nil {
+				values[field.Key] = value
+			}
+		case reflect.Int:
+			value, err := strconv.Atoi(field.Default)
+			if err == nil {
+				values[field.Key] = value
+			}
+		default:
+			values[field.Key] = field.Default
+		}
+	}
+	return values
+}
+
+// mergeConfigValues merges the override layer into base. Nested maps are
+// merged key by key, every other value (including lists) replaces the base
+// value. Keys present in override win even when their value is empty, so a
+// local file can clear a value set by the global one.
+//
+// Parameters:
+//   - base: The layer to merge into (modified in place)
+//   - override: The higher priority layer
+func mergeConfigValues(base, override map[string]interface{}) {
+	for key, value := range override {
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+		baseMap, baseIsMap := base[key].(map[string]interface{})
+		if overrideIsMap && baseIsMap {
+			merged := make(map[string]interface{}, len(baseMap))
+			for k, v := range baseMap {
+				merged[k] = v
+			}
+			mergeConfigValues(merged, overrideMap)
+			base[key] = merged
+			continue
+		}
+		base[key] = value
+	}
+}
+
+// decodeConfigValues converts a raw configuration layer into a YamlConfig.
+//
+// Parameters:
+//   - values: The raw layer as read from YAML
+//
+// Returns:
+//   - YamlConfig: The decoded configuration
+//   - error: Error if a value has the wrong type
+func decodeConfigValues(values map[string]interface{}) (YamlConfig, error) {
+	var config YamlConfig
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return config, err
+	}
+	err = yaml.Unmarshal(data, &config)
+	return config, err
+}
+
+// canonicalizeConfigKeys renames keys that differ from a known key only in
+// case (e.g., "DevBaseBranch" -> "devBaseBranch"), recursing into struct and
+// map fields, so config files behave the same as with the former
+// case-insensitive loader.
+func canonicalizeConfigKeys(values map[string]interface{}, t reflect.Type) {
+	if t.Kind() == reflect.Map {
+		for _, value := range values {
+			if nested, ok := value.(map[string]interface{}); ok && t.Elem().Kind() == reflect.Struct {
+				canonicalizeConfigKeys(nested, t.Elem())
+			}
+		}
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := yamlKey(sf)
+		if key == "" {
+			continue
+		}
+		for name, value := range values {
+			if name != key && strings.EqualFold(name, key) {
+				if _, exists := values[key]; !exists {
+					values[key] = value
+					delete(values, name)
+				}
+			}
+		}
+
+		nested, ok := values[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fieldType := sf.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct || fieldType.Kind() == reflect.Map {
+			canonicalizeConfigKeys(nested, fieldType)
+		}
+	}
+}
+
+// lookupConfigValue reads a dot-separated path (e.g., "issueTracker.url")
+// from a raw configuration layer.
+//
+// Returns:
+//   - interface{}: The value at the path
+//   - bool: false if the path is not present in the layer
+func lookupConfigValue(values map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = values
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// FormatConfigValue renders a configuration value for display. Scalars are
+// printed as-is, maps and structs as YAML without empty entries.
+//
+// Parameters:
+//   - value: The value to render
+//
+// Returns:
+//   - string: The rendered value, possibly spanning several lines
+func FormatConfigValue(value interface{}) string {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice:
+		if v.IsZero() || (v.Kind() != reflect.Struct && v.Len() == 0) {
+			return ""
+		}
+		var b strings.Builder
+		encoder := yaml.NewEncoder(&b)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return strings.TrimRight(b.String(), "\n")
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,394 @@
+package utils
+
+import (
+	"fmt"
+	gflstrings "gfl/utils/strings"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigIssue is a problem found by ValidateConfigFile or ValidateConfigBranches.
+type ConfigIssue struct {
+	// File is the configuration file the problem was found in
+	File string
+
+	// Line is the line number in the file (0 if unknown)
+	Line int
+
+	// Key is the dot-separated key the problem refers to
+	Key string
+
+	// Message describes the problem
+	Message string
+}
+
+// String formats the issue as "file:line: key: message".
+func (issue ConfigIssue) String() string {
+	location := issue.File
+	if issue.Line > 0 {
+		location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, issue.Key, issue.Message)
+}
+
+// ValidateConfigFile checks a configuration file for unknown keys, values
+// of the wrong type and values outside the allowed set. Unknown keys come
+// with a did-you-mean suggestion when a known key is close enough.
+//
+// Parameters:
+//   - filename: The configuration file to check
+//
+// Returns:
+//   - []ConfigIssue: The problems found, empty if the file is valid or missing
+func ValidateConfigFile(filename string) []ConfigIssue {
+	if !fileExists(filename) {
+		return nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return []ConfigIssue{{File: filename, Message: err.Error()}}
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return []ConfigIssue{{File: filename, Message: err.Error()}}
+	}
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	var issues []ConfigIssue
+	validateConfigNode(filename, document.Content[0], reflect.TypeOf(YamlConfig{}), "", nil, &issues)
+	return issues
+}
+
+// validateConfigNode checks a YAML node against the Go type it decodes into.
+func validateConfigNode(filename string, node *yaml.Node, t reflect.Type, path string, enum []string, issues *[]ConfigIssue) {
+	report := func(line int, message string) {
+		*issues = append(*issues, ConfigIssue{File: filename, Line: line, Key: path, Message: message})
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			report(node.Line, gflstrings.GetPath("config.validate.expected_mapping"))
+			return
+		}
+		var known []string
+		for i := 0; i < t.NumField(); i++ {
+			if key := yamlKey(t.Field(i)); key != "" {
+				known = append(known, key)
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			childPath := joinConfigPath(path, keyNode.Value)
+
+			if path == "" && keyNode.Value == extendsKey {
+				validateExtendsNode(filename, valueNode, issues)
+				continue
+			}
+
+			sf, ok := structFieldByKeyFold(t, keyNode.Value)
+			if !ok {
+				message := gflstrings.GetPath("config.validate.unknown_key")
+				if suggestion := suggestConfigKey(keyNode.Value, known); suggestion != "" {
+					message += gflstrings.GetPath("config.validate.did_you_mean", joinConfigPath(path, suggestion))
+				}
+				*issues = append(*issues, ConfigIssue{File: filename, Line: keyNode.Line, Key: childPath, Message: message})
+				continue
+			}
+
+			var childEnum []string
+			if tag := sf.Tag.Get("enum"); tag != "" {
+				childEnum = strings.Split(tag, ",")
+			}
+			validateConfigNode(filename, valueNode, sf.Type, childPath, childEnum, issues)
+		}
+
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			report(node.Line, gflstrings.GetPath("config.validate.expected_mapping"))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := joinConfigPath(path, node.Content[i].Value)
+			validateConfigNode(filename, node.Content[i+1], t.Elem(), childPath, nil, issues)
+		}
+
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			report(node.Line, gflstrings.GetPath("config.validate.expected_list"))
+			return
+		}
+		for i, item := range node.Content {
+			validateConfigNode(filename, item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), nil, issues)
+		}
+
+	default:
+		if node.Kind != yaml.ScalarNode {
+			report(node.Line, gflstrings.GetPath("config.validate.expected_type", t.Kind()))
+			return
+		}
+		if node.Tag == "!!null" {
+			return
+		}
+		if err := node.Decode(reflect.New(t).Interface()); err != nil {
+			report(node.Line, gflstrings.GetPath("config.validate.expected_type", t.Kind()))
+			return
+		}
+		if len(enum) > 0 && !containsString(enum, node.Value) {
+			report(node.Line, gflstrings.GetPath("config.validate.invalid_enum", node.Value, strings.Join(enum, ", ")))
+		}
+	}
+}
+
+// validateExtendsNode checks that extends holds a reference or a list of them.
+func validateExtendsNode(filename string, node *yaml.Node, issues *[]ConfigIssue) {
+	items := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		items = node.Content
+	}
+	for _, item := range items {
+		if item.Kind != yaml.ScalarNode || item.Tag == "!!null" {
+			*issues = append(*issues, ConfigIssue{
+				File:    filename,
+				Line:    item.Line,
+				Key:     extendsKey,
+				Message: gflstrings.GetPath("config.validate.expected_type", reflect.String),
+			})
+			continue
+		}
+
+		parent, err := resolveExtends(configRef{path: filename}, item.Value)
+		if err == nil && parent.ref != "" {
+			if output, gitErr := exec.Command("git", "cat-file", "-e", parent.String()).CombinedOutput(); gitErr != nil {
+				err = fmt.Errorf("%s", strings.TrimSpace(string(output)))
+			}
+		}
+		if err != nil {
+			*issues = append(*issues, ConfigIssue{
+				File:    filename,
+				Line:    item.Line,
+				Key:     extendsKey,
+				Message: gflstrings.GetPath("config.extends_failed", item.Value, err),
+			})
+		}
+	}
+}
+
+// ValidateConfigBranches checks that the branches named in the configuration
+// exist on the remote: devBaseBranch, productionBranch and the base branch
+// of every branch type.
+//
+// Parameters:
+//   - info: The configuration with its sources, used to report where a branch was set
+//
+// Returns:
+//   - []ConfigIssue: One issue per missing branch
+//   - error: Error if the remote branches cannot be listed
+func ValidateConfigBranches(info ConfigInfo) ([]ConfigIssue, error) {
+	remoteBranches, err := GetRemoteBranches()
+	if err != nil {
+		return nil, err
+	}
+	exists := map[string]bool{}
+	for _, branch := range remoteBranches {
+		exists[strings.TrimPrefix(branch, "origin/")] = true
+	}
+
+	config := info.FinalConfig
+	branches := map[string]string{
+		"devBaseBranch":    config.DevBaseBranch,
+		"productionBranch": config.ProductionBranch,
+	}
+	for name, branchType := range config.BranchTypes {
+		if branchType.Base != "" {
+			branches["branchTypes."+name+".base"] = branchType.Base
+		}
+	}
+
+	var keys []string
+	for key := range branches {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var issues []ConfigIssue
+	for _, key := range keys {
+		branch := branches[key]
+		if branch == "" || exists[branch] {
+			continue
+		}
+		file := gflstrings.GetPath("config.default_value")
+		if source, ok := info.Origin(key); ok {
+			file = source.Path
+		}
+		issues = append(issues, ConfigIssue{
+			File:    file,
+			Key:     key,
+			Message: gflstrings.GetPath("config.validate.missing_branch", branch),
+		})
+	}
+	return issues, nil
+}
+
+// ConfigJSONSchema builds a JSON Schema (draft-07) of the configuration file
+// from YamlConfig, for editor completion and validation of .gfl.config.yml.
+//
+// Returns:
+//   - map[string]interface{}: The schema, ready to be encoded as JSON
+func ConfigJSONSchema() map[string]interface{} {
+	schema := jsonSchemaForType(reflect.TypeOf(YamlConfig{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "gfl configuration"
+
+	properties := schema["properties"].(map[string]interface{})
+	properties[extendsKey] = map[string]interface{}{
+		"title": gflstrings.GetPath("config.extends"),
+		"oneOf": []interface{}{
+			map[string]interface{}{"type": "string"},
+			map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+	}
+	for _, field := range ConfigFields() {
+		property := properties[field.Key].(map[string]interface{})
+		if field.Label != "" {
+			property["title"] = gflstrings.GetPath(field.Label)
+		}
+		if field.Default != "" {
+			switch field.Type.Kind() {
+			case reflect.Bool:
+				property["default"], _ = strconv.ParseBool(field.Default)
+			case reflect.Int:
+				property["default"], _ = strconv.Atoi(field.Default)
+			default:
+				property["default"] = field.Default
+			}
+		}
+	}
+	return schema
+}
+
+// jsonSchemaForType converts a Go type into a JSON Schema fragment.
+func jsonSchemaForType(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			key := yamlKey(sf)
+			if key == "" {
+				continue
+			}
+			property := jsonSchemaForType(sf.Type)
+			if enum := sf.Tag.Get("enum"); enum != "" {
+				property["enum"] = strings.Split(enum, ",")
+			}
+			properties[key] = property
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": jsonSchemaForType(t.Elem()),
+		}
+	case reflect.Slice:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": jsonSchemaForType(t.Elem()),
+		}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// structFieldByKeyFold finds a struct field by YAML key, ignoring case like
+// the config loader does.
+func structFieldByKeyFold(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if name := yamlKey(t.Field(i)); name != "" && strings.EqualFold(name, key) {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// suggestConfigKey returns the known key closest to an unknown one, or empty
+// string if none is close enough to be a likely typo.
+func suggestConfigKey(key string, known []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range known {
+		distance := levenshtein(strings.ToLower(key), strings.ToLower(candidate))
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	// Allow roughly one typo per four characters, at least two
+	limit := len(key) / 4
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDistance < 0 || bestDistance > limit {
+		return ""
+	}
+	return best
+}
+
+// levenshtein computes the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// joinConfigPath appends a key to a dot-separated key path.
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"os"
+	"reflect"
 	str "strings"
 
 	"gfl/utils/strings"
@@ -22,28 +23,27 @@ type CreateGflConfigOptions struct {
 	AddGitIgnore bool
 }
 
-// AddGitIgnore adds GFL configuration files to the .gitignore.
-// This is a legacy function that adds the hardcoded .gflow.config.yml to .gitignore.
-// It silently fails if the .gitignore file doesn't exist or if there are errors.
-//
-// Note: This function is deprecated. Use CreateGflConfig with AddGitIgnore option instead.
+// AddGitIgnore adds the local configuration file (.gfl.config.local.yml) to
+// the .gitignore at the repository root, creating .gitignore if needed.
+// Nothing is written when the file is already listed. Errors are ignored as
+// they shouldn't prevent config creation.
 func AddGitIgnore() {
-	// Check if .gitignore file exists
-	if _, err := os.Stat(".gitignore"); os.IsNotExist(err) {
-		return
+	gitignore := RepoConfigPath(".gitignore")
+	content, _ := os.ReadFile(gitignore)
+	for _, line := range str.Split(string(content), "\n") {
+		if str.TrimSpace(line) == LocalConfigFile {
+			Info(strings.GetPath("init.gitignore_skip"))
+			return
+		}
 	}
 
-	// Add `.gflow.config.yml` to `.gitignore`
-	f, err := os.OpenFile(".gitignore", os.O_APPEND|os.O_WRONLY, 0600)
+	f, err := os.OpenFile(gitignore, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
 	if err != nil {
 		return
 	}
 	defer f.Close()
 
-	_, err = f.WriteString("\n.gflow.config.yml\n")
-	if err != nil {
-		return
-	}
+	_, _ = f.WriteString(fmt.Sprintf("\n%s\n", LocalConfigFile))
 }
 
 // CreateGflConfig creates a GFL configuration file with the specified options.
@@ -193,26 +193,13 @@ func RemoveEmptyFields(config *YamlConfig) *YamlConfig {
 	cleanConfig := &YamlConfig{}
 
 	// Only preserve non-zero values
-	if config.Debug {
-		cleanConfig.Debug = config.Debug
-	}
-	if config.DevBaseBranch != "" {
-		cleanConfig.DevBaseBranch = config.DevBaseBranch
-	}
-	if config.ProductionBranch != "" {
-		cleanConfig.ProductionBranch = config.ProductionBranch
-	}
-	if config.Nickname != "" {
-		cleanConfig.Nickname = config.Nickname
-	}
-	if config.FeaturePrefix != "" {
-		cleanConfig.FeaturePrefix = config.FeaturePrefix
-	}
-	if config.FixPrefix != "" {
-		cleanConfig.FixPrefix = config.FixPrefix
-	}
-	if config.HotfixPrefix != "" {
-		cleanConfig.HotfixPrefix = config.HotfixPrefix
+	source := reflect.ValueOf(config).Elem()
+	target := reflect.ValueOf(cleanConfig).Elem()
+	for _, field := range ConfigFields() {
+		value := source.Field(field.index)
+		if !value.IsZero() {
+			target.Field(field.index).Set(value)
+		}
 	}
 
 	return cleanConfig
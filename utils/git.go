@@ -76,6 +76,20 @@ func GetRepository() (string, error) {
 	return extractOwnerAndRepo(cleanURL)
 }
 
+// GetRepositoryRoot returns the top-level directory of the current Git
+// working tree, as reported by 'git rev-parse --show-toplevel'.
+//
+// Returns:
+//   - string: The absolute path of the repository root
+//   - error: Error if not in a Git repository
+func GetRepositoryRoot() (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // GetCurrentBranch retrieves the name of the current Git branch.
 // It uses 'git rev-parse --abbrev-ref HEAD' to get the branch name,
 // which is a reliable method that works in all Git scenarios.
@@ -166,3 +180,137 @@ func RemoteBranchExists(branchName string) (bool, error) {
 
 	return false, nil
 }
+
+// GetLocalBranchNames retrieves the names of all local branches.
+// Unlike GetLocalBranches, the result contains clean branch names without
+// the current-branch marker or indentation, which makes it safe to pass
+// the names straight back to git commands.
+//
+// Returns:
+//   - []string: List of local branch names (e.g., ["main", "feature/aric/user-auth"])
+//   - error: Error if the command execution fails
+func GetLocalBranchNames() ([]string, error) {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local branches: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(string(output), "\n") {
+		branch := strings.TrimSpace(line)
+		if branch == "" {
+			continue
+		}
+		branches = append(branches, branch)
+	}
+
+	return branches, nil
+}
+
+// GetBranchConfig reads a per-branch git config value (branch.<name>.<key>).
+// gfl stores its branch metadata, such as stack parents, alongside git's own
+// branch settings so it survives across sessions and is removed by git when
+// the branch is deleted.
+//
+// Parameters:
+//   - branch: The local branch name
+//   - key: The variable name inside the branch section (e.g., "gfl-parent")
+//
+// Returns:
+//   - string: The configured value, or empty string if unset
+func GetBranchConfig(branch, key string) string {
+	output, err := exec.Command("git", "config", "--get", fmt.Sprintf("branch.%s.%s", branch, key)).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// SetBranchConfig writes a per-branch git config value (branch.<name>.<key>).
+//
+// Parameters:
+//   - branch: The local branch name
+//   - key: The variable name inside the branch section
+//   - value: The value to store
+//
+// Returns:
+//   - error: Error if git config fails
+func SetBranchConfig(branch, key, value string) error {
+	if err := exec.Command("git", "config", fmt.Sprintf("branch.%s.%s", branch, key), value).Run(); err != nil {
+		return fmt.Errorf("failed to set branch.%s.%s: %w", branch, key, err)
+	}
+	return nil
+}
+
+// GetBranchConfigs reads one per-branch git config key for every branch that has it.
+//
+// Parameters:
+//   - key: The variable name inside the branch section (e.g., "gfl-parent")
+//
+// Returns:
+//   - map[string]string: Branch name to configured value
+func GetBranchConfigs(key string) map[string]string {
+	result := map[string]string{}
+	pattern := fmt.Sprintf(`^branch\..*\.%s$`, key)
+	output, err := exec.Command("git", "config", "--get-regexp", pattern).Output()
+	if err != nil {
+		// git exits with 1 when nothing matches
+		return result
+	}
+
+	suffix := "." + strings.ToLower(key)
+	for _, line := range strings.Split(string(output), "\n") {
+		name, value, found := strings.Cut(strings.TrimSpace(line), " ")
+		if !found || !strings.HasPrefix(name, "branch.") {
+			continue
+		}
+		// git lowercases the variable name but keeps the branch subsection as-is
+		branch := strings.TrimPrefix(name, "branch.")
+		if !strings.HasSuffix(strings.ToLower(branch), suffix) {
+			continue
+		}
+		branch = branch[:len(branch)-len(suffix)]
+		result[branch] = value
+	}
+	return result
+}
+
+// GetCommitSHA resolves a revision (branch, remote branch, tag) to its commit SHA.
+//
+// Parameters:
+//   - ref: Any revision git understands (e.g., "origin/dev", "HEAD")
+//
+// Returns:
+//   - string: The full commit SHA
+//   - error: Error if the revision cannot be resolved
+func GetCommitSHA(ref string) (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// LocalBranchExists checks if a local branch with the given name exists.
+//
+// Parameters:
+//   - branchName: The branch name (without "refs/heads/")
+//
+// Returns:
+//   - bool: true if the branch exists locally
+func LocalBranchExists(branchName string) bool {
+	return exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branchName).Run() == nil
+}
+
+// IsAncestor reports whether commit ancestor is reachable from descendant.
+//
+// Parameters:
+//   - ancestor: The possible ancestor revision
+//   - descendant: The revision to walk back from
+//
+// Returns:
+//   - bool: true if ancestor is an ancestor of (or equal to) descendant
+func IsAncestor(ancestor, descendant string) bool {
+	return exec.Command("git", "merge-base", "--is-ancestor", ancestor, descendant).Run() == nil
+}
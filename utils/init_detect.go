@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"os/exec"
+	"strings"
+	"unicode"
+)
+
+// GetRemoteDefaultBranch returns the default branch of the origin remote,
+// read from refs/remotes/origin/HEAD.
+//
+// Returns:
+//   - string: The branch name without the remote (e.g., "main")
+//   - error: Error if origin/HEAD is not set (run 'git remote set-head origin -a')
+func GetRemoteDefaultBranch() (string, error) {
+	output, err := exec.Command("git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/"), nil
+}
+
+// SuggestNickname derives a branch nickname from 'git config user.name':
+// the first word, lowercased and converted to an ASCII slug, so
+// "Aric Chen" becomes "aric" and "张三" becomes "zhang-san".
+//
+// Returns:
+//   - string: The suggested nickname, or empty string if user.name is not set
+func SuggestNickname() string {
+	output, err := exec.Command("git", "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return ""
+	}
+	return Slugify(transliterate(fields[0], "pinyin"))
+}
+
+// DetectBranchCaseFormat guesses the branchCaseFormat used by existing
+// branches from the last component of names such as "feature/aric/user-login".
+// Names that fit several formats (e.g., "login") are not counted.
+//
+// Parameters:
+//   - branches: The branch names to inspect
+//
+// Returns:
+//   - string: The most common format, or "original" if none is recognized
+func DetectBranchCaseFormat(branches []string) string {
+	counts := map[string]int{}
+	for _, branch := range branches {
+		if !strings.Contains(branch, "/") {
+			// main, dev and other long-lived branches say nothing about the format
+			continue
+		}
+		if format := branchNameCaseFormat(branch[strings.LastIndex(branch, "/")+1:]); format != "" {
+			counts[format]++
+		}
+	}
+
+	best, bestCount := "original", 0
+	for _, format := range []string{"kebab", "snake", "camel", "pascal", "upper"} {
+		if counts[format] > bestCount {
+			best, bestCount = format, counts[format]
+		}
+	}
+	return best
+}
+
+// branchNameCaseFormat classifies one name, or returns empty string if the
+// name is ambiguous or mixes formats.
+func branchNameCaseFormat(name string) string {
+	hasUpper, hasLower := false, false
+	for _, r := range name {
+		hasUpper = hasUpper || unicode.IsUpper(r)
+		hasLower = hasLower || unicode.IsLower(r)
+	}
+	hasHyphen := strings.Contains(name, "-")
+	hasUnderscore := strings.Contains(name, "_")
+
+	switch {
+	case hasHyphen && hasUnderscore:
+		return ""
+	case hasHyphen && !hasUpper:
+		return "kebab"
+	case hasUnderscore && !hasUpper:
+		return "snake"
+	case hasHyphen || hasUnderscore:
+		return ""
+	case hasUpper && !hasLower:
+		return "upper"
+	case hasUpper && hasLower:
+		if unicode.IsUpper([]rune(name)[0]) {
+			return "pascal"
+		}
+		return "camel"
+	default:
+		// A single lowercase word fits every lowercase format
+		return ""
+	}
+}
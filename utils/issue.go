@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// branchIssueKey stores the issue id in branch.<name>.gfl-issue
+	branchIssueKey = "gfl-issue"
+
+	// branchIssueTitleKey stores the issue title in branch.<name>.gfl-issue-title
+	branchIssueTitleKey = "gfl-issue-title"
+
+	// branchIssueURLKey stores the issue link in branch.<name>.gfl-issue-url
+	branchIssueURLKey = "gfl-issue-url"
+)
+
+// issueRefPattern matches issue references such as "#123" or "GH-123".
+var issueRefPattern = regexp.MustCompile(`^(?:#|(?i:gh)-)(\d+)$`)
+
+// Issue describes an issue fetched from the hosting provider or issue tracker.
+type Issue struct {
+	// ID is the issue identifier (e.g., "123")
+	ID string
+
+	// Title is the issue title
+	Title string
+
+	// URL is the web link to the issue
+	URL string
+}
+
+// ParseIssueRef extracts the issue id from an issue reference argument.
+//
+// Parameters:
+//   - ref: The user input (e.g., "#123", "GH-123", "gh-123")
+//
+// Returns:
+//   - string: The numeric issue id
+//   - bool: true if the input is an issue reference
+//
+// Examples:
+//   - ParseIssueRef("#123") -> "123", true
+//   - ParseIssueRef("GH-42") -> "42", true
+//   - ParseIssueRef("login-page") -> "", false
+func ParseIssueRef(ref string) (string, bool) {
+	matches := issueRefPattern.FindStringSubmatch(ref)
+	if matches == nil {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// LookupIssue fetches an issue by id. When an issue tracker URL is configured
+// it is queried over HTTP, otherwise the hosting provider is queried through
+// the GitHub CLI.
+//
+// Parameters:
+//   - config: The configuration containing the optional issue tracker settings
+//   - id: The issue id
+//
+// Returns:
+//   - *Issue: The issue with its title and link
+//   - error: Error if the lookup fails or the issue has no title
+func LookupIssue(config *YamlConfig, id string) (*Issue, error) {
+	if config.IssueTracker.URL != "" {
+		return lookupTrackerIssue(config.IssueTracker, id)
+	}
+	return lookupGitHubIssue(id)
+}
+
+// lookupGitHubIssue fetches an issue through 'gh issue view'.
+func lookupGitHubIssue(id string) (*Issue, error) {
+	if !IsCommandAvailable("gh") {
+		return nil, fmt.Errorf("gh cli is not installed")
+	}
+
+	output, err := exec.Command("gh", "issue", "view", id, "--json", "number,title,url").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue #%s: %w", id, err)
+	}
+
+	var data struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		URL    string `json:"url"`
+	}
+	if err := json.Unmarshal(output, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse issue data: %w", err)
+	}
+
+	return &Issue{ID: id, Title: data.Title, URL: data.URL}, nil
+}
+
+// lookupTrackerIssue fetches an issue from a configured issue tracker.
+// The URL template's {id} placeholder is replaced with the issue id and the
+// title and link are read from the JSON response using dot-separated paths.
+func lookupTrackerIssue(tracker IssueTrackerConfig, id string) (*Issue, error) {
+	url := strings.ReplaceAll(tracker.URL, "{id}", id)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch issue %s: %s", id, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue %s: %w", id, err)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse issue data: %w", err)
+	}
+
+	titleField := tracker.TitleField
+	if titleField == "" {
+		titleField = "title"
+	}
+	title := jsonPathString(data, titleField)
+	if title == "" {
+		return nil, fmt.Errorf("issue %s has no field %q", id, titleField)
+	}
+
+	// Use the API URL as link when no link field is mapped or present
+	link := url
+	if tracker.LinkField != "" {
+		if value := jsonPathString(data, tracker.LinkField); value != "" {
+			link = value
+		}
+	}
+
+	return &Issue{ID: id, Title: title, URL: link}, nil
+}
+
+// jsonPathString reads a string value from decoded JSON using a dot-separated path.
+//
+// Examples:
+//   - jsonPathString(data, "title") -> data["title"]
+//   - jsonPathString(data, "fields.summary") -> data["fields"]["summary"]
+func jsonPathString(data interface{}, path string) string {
+	current := data
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = object[key]
+	}
+
+	switch value := current.(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprintf("%v", value)
+	default:
+		return ""
+	}
+}
+
+// RecordBranchIssue stores the issue a branch was started from, so that the
+// pull request body can link back to it later.
+//
+// Parameters:
+//   - branch: The branch name
+//   - issue: The issue the branch was created for
+//
+// Returns:
+//   - error: Error if the metadata cannot be written
+func RecordBranchIssue(branch string, issue *Issue) error {
+	if err := SetBranchConfig(branch, branchIssueKey, issue.ID); err != nil {
+		return err
+	}
+	if err := SetBranchConfig(branch, branchIssueTitleKey, issue.Title); err != nil {
+		return err
+	}
+	return SetBranchConfig(branch, branchIssueURLKey, issue.URL)
+}
+
+// GetBranchIssue returns the issue recorded for a branch.
+//
+// Returns:
+//   - *Issue: The recorded issue, or nil if the branch was not started from an issue
+func GetBranchIssue(branch string) *Issue {
+	id := GetBranchConfig(branch, branchIssueKey)
+	if id == "" {
+		return nil
+	}
+	return &Issue{
+		ID:    id,
+		Title: GetBranchConfig(branch, branchIssueTitleKey),
+		URL:   GetBranchConfig(branch, branchIssueURLKey),
+	}
+}
+
+// BuildPrBody generates the pull request body for a branch started from an issue.
+// GitHub issue links use a closing keyword so the issue is closed on merge.
+//
+// Parameters:
+//   - issue: The issue recorded for the branch
+//
+// Returns:
+//   - string: The pull request body
+func BuildPrBody(issue *Issue) string {
+	if strings.Contains(issue.URL, "github.com") {
+		return fmt.Sprintf("Closes %s", issue.URL)
+	}
+	return fmt.Sprintf("Issue: %s", issue.URL)
+}
@@ -2,86 +2,244 @@ package utils
 
 import (
 	"fmt"
+	"gfl/utils/strings"
+	"os/exec"
+	"sort"
+	str "strings"
+	"time"
+	"unicode"
+
 	"github.com/AlecAivazis/survey/v2"
-	"strings"
+	"github.com/fatih/color"
 )
 
-// BuildCommandList creates an interactive branch selection interface for Git checkout.
-// This function presents the user with a selection list of available branches and
-// automatically executes the git checkout command for the selected branch.
+// BranchChoice is a branch offered by the interactive checkout.
+type BranchChoice struct {
+	// BranchDetails describes the branch tip (Ref is the remote ref for remote-only branches)
+	BranchDetails
+
+	// RemoteOnly is true for origin branches without a local branch; they are
+	// tracked when selected
+	RemoteOnly bool
+
+	// LastUsed is the most recent of the last commit and the last checkout
+	LastUsed time.Time
+}
+
+// ListBranchChoices lists the local branches and the origin branches that have
+// no local branch, most recently used first. A branch counts as used when it
+// gets a commit, or when HEAD moves to or away from it (read from the HEAD reflog).
+// The current branch is left out.
+//
+// Parameters:
+//   - filter: Optional fuzzy filter (see FuzzyMatch), empty lists every branch
 //
-// The function uses the survey library to create a terminal-based interactive prompt
-// that provides a user-friendly way to switch between branches without needing
-// to remember or type the full branch names.
+// Returns:
+//   - []BranchChoice: The branches, most recently used first
+//   - error: Error if the branches cannot be listed
+func ListBranchChoices(filter string) ([]BranchChoice, error) {
+	local, err := ListBranchDetails(false)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := ListBranchDetails(true)
+	if err != nil {
+		return nil, err
+	}
+	checkouts := lastCheckouts()
+	currentBranch, _ := GetCurrentBranch()
+
+	var choices []BranchChoice
+	add := func(details BranchDetails, remoteOnly bool) {
+		if details.Name == currentBranch || !FuzzyMatch(filter, details.Name) {
+			return
+		}
+		choice := BranchChoice{BranchDetails: details, RemoteOnly: remoteOnly, LastUsed: details.LastCommit}
+		if checkout, found := checkouts[details.Name]; found && checkout.After(choice.LastUsed) {
+			choice.LastUsed = checkout
+		}
+		choices = append(choices, choice)
+	}
+	for _, details := range local {
+		add(details, false)
+	}
+	for name, details := range remote {
+		if _, found := local[name]; !found {
+			add(details, true)
+		}
+	}
+
+	sort.SliceStable(choices, func(i, j int) bool {
+		if !choices[i].LastUsed.Equal(choices[j].LastUsed) {
+			return choices[i].LastUsed.After(choices[j].LastUsed)
+		}
+		return choices[i].Name < choices[j].Name
+	})
+	return choices, nil
+}
+
+// lastCheckouts reads when HEAD last moved to or away from each branch.
+func lastCheckouts() map[string]time.Time {
+	result := map[string]time.Time{}
+	output, err := exec.Command("git", "log", "-g", "-n", "1000", "--format=%ct%x00%gs", "HEAD").Output()
+	if err != nil {
+		// No reflog yet
+		return result
+	}
+	for _, line := range str.Split(str.TrimSpace(string(output)), "\n") {
+		unix, subject, found := str.Cut(line, "\x00")
+		if !found || !str.HasPrefix(subject, "checkout: moving from ") {
+			continue
+		}
+		var seconds int64
+		if _, err := fmt.Sscanf(unix, "%d", &seconds); err != nil {
+			continue
+		}
+		// "checkout: moving from <from> to <to>", newest first
+		from, to, _ := str.Cut(str.TrimPrefix(subject, "checkout: moving from "), " to ")
+		for _, branch := range []string{from, to} {
+			if _, seen := result[branch]; !seen {
+				result[branch] = time.Unix(seconds, 0)
+			}
+		}
+	}
+	return result
+}
+
+// FuzzyMatch reports whether the characters of pattern appear in value in the
+// same order, ignoring case (e.g., "fal" matches "feature/aric/login").
+// Spaces in the pattern are ignored and an empty pattern matches everything.
 //
 // Parameters:
-//   - branches: A slice of branch names available for selection
+//   - pattern: The fuzzy pattern
+//   - value: The text to match
 //
-// Side Effects:
-//   - Displays an interactive selection prompt in the terminal
-//   - Executes 'git checkout' command for the selected branch
-//   - Logs success or error messages
+// Returns:
+//   - bool: true if value matches
+func FuzzyMatch(pattern, value string) bool {
+	remaining := []rune(str.ToLower(value))
+	for _, r := range str.ToLower(pattern) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		index := -1
+		for i, candidate := range remaining {
+			if candidate == r {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return false
+		}
+		remaining = remaining[index+1:]
+	}
+	return true
+}
+
+// BuildCommandList creates an interactive branch selection interface for Git checkout.
+// Branches are listed most recently used first; typing filters them fuzzily
+// (see FuzzyMatch). Each branch shows a preview with the age, author and
+// subject of its last commit and its position relative to base. The selected
+// branch is checked out by the caller with CheckoutBranchChoice, so that it
+// can stash changes around the switch.
 //
-// User Experience:
-//   - Shows a numbered or arrow-key navigable list of branches
-//   - Supports keyboard navigation and selection
-//   - Provides immediate feedback on selection
-//   - Automatically switches to the selected branch
+// Parameters:
+//   - choices: The branches available for selection (see ListBranchChoices)
+//   - base: The ref ahead/behind counts are computed against (e.g., "dev"),
+//     empty to leave them out
 //
-// Error Handling:
-//   - Logs survey interaction errors
-//   - Logs git checkout command execution errors
-//   - Continues execution even if the operation fails
+// Returns:
+//   - BranchChoice: The selected branch
+//   - bool: false if the prompt failed or was cancelled (the error is logged)
 //
 // Example Output:
-//   ? Choose a branch:
-//     ▸ main
-//       develop
-//       feature/aric/user-auth
-//       hotfix/security-fix
-func BuildCommandList(branches []string) {
-	// Define the structure to hold the survey answer
-	answers := struct {
-		Module string `survey:"branch"` // The survey field name and corresponding struct field
-	}{}
-
-	// Create the survey question with a select prompt
-	var qs = []*survey.Question{
-		{
-			Name: "module",
-			Prompt: &survey.Select{
-				Message: "Choose a branch:",
-				Options: branches,
-				// Additional options could include:
-				// - Help text for the selection
-				// - Default selection
-				// - Filtering options
-			},
-		},
+//
+//	? Choose a branch:
+//	  ▸ feature/aric/user-auth - 2h · Aric · feat: add login · dev ↑3 ↓0
+//	    main - 3d · Bob · chore: release 1.2.0
+//	    origin/feature/bob/payment - 5d · Bob · wip: payment · dev ↑1 ↓4
+func BuildCommandList(choices []BranchChoice, base string) (BranchChoice, bool) {
+	options := make([]string, len(choices))
+	for i, choice := range choices {
+		options[i] = choice.Name
+		if choice.RemoteOnly {
+			options[i] = "origin/" + choice.Name
+		}
+	}
+
+	// Ahead/behind counts run one git command per branch, only for the
+	// branches actually displayed
+	previews := map[int]string{}
+	describe := func(value string, index int) string {
+		if preview, found := previews[index]; found {
+			return preview
+		}
+		choice := choices[index]
+		parts := []string{FormatAge(choice.LastCommit), choice.AuthorName, choice.Subject}
+		if base != "" {
+			if ahead, behind, err := CountAheadBehind(choice.Ref, base); err == nil {
+				parts = append(parts, fmt.Sprintf("%s ↑%d ↓%d", str.TrimPrefix(base, "origin/"), ahead, behind))
+			}
+		}
+		previews[index] = str.Join(parts, " · ")
+		return previews[index]
 	}
 
-	// Execute the interactive survey
-	err := survey.Ask(qs, &answers)
+	var selected int
+	err := survey.AskOne(&survey.Select{
+		Message:     strings.GetPath("checkout.choose_branch"),
+		Options:     options,
+		Description: describe,
+		Filter: func(filter string, value string, index int) bool {
+			return FuzzyMatch(filter, value)
+		},
+		PageSize: 10,
+	}, &selected)
 	if err != nil {
 		Error(fmt.Sprintf("Survey interaction failed: %v", err))
-		return
+		return BranchChoice{}, false
 	}
+	return choices[selected], true
+}
 
-	// Execute git checkout command for the selected branch
-	selectedBranch := answers.Module
-	command := fmt.Sprintf("git checkout %s", selectedBranch)
-
-	// Run the checkout command and handle any errors
-	output, err := RunShell(command)
+// CheckoutBranchChoice switches to a branch, creating a local branch tracking
+// origin for remote-only branches.
+//
+// Parameters:
+//   - choice: The branch to switch to
+//
+// Returns:
+//   - bool: true if the branch was checked out
+func CheckoutBranchChoice(choice BranchChoice) bool {
+	args := []string{"checkout", choice.Name}
+	if choice.RemoteOnly {
+		args = []string{"checkout", "--track", "origin/" + choice.Name}
+	}
+	output, err := exec.Command("git", args...).CombinedOutput()
 	if err != nil {
-		Error(fmt.Sprintf("Failed to checkout branch '%s': %v", selectedBranch, err))
+		Errorf(strings.GetPath("checkout.checkout_error", choice.Name, str.TrimSpace(string(output))))
+		return false
+	}
+
+	if choice.RemoteOnly {
+		Successf(strings.GetPath("checkout.tracked", color.GreenString(choice.Name), "origin/"+choice.Name))
 	} else {
-		// Provide feedback on successful checkout
-		Successf("Successfully checked out branch: %s", selectedBranch)
+		Successf(strings.GetPath("checkout.switched", color.GreenString(choice.Name)))
+	}
+	return true
+}
 
-		// Show any output from the git command if it exists
-		if len(strings.TrimSpace(output)) > 0 {
-			Infof("Git output: %s", strings.TrimSpace(output))
-		}
+// CheckoutPreviousBranch switches back to the branch checked out before the
+// current one, like 'git checkout -'.
+//
+// Returns:
+//   - bool: true if the branch was checked out
+func CheckoutPreviousBranch() bool {
+	previous, err := exec.Command("git", "rev-parse", "--abbrev-ref", "@{-1}").Output()
+	if err != nil {
+		Error(strings.GetPath("checkout.no_previous"))
+		return false
 	}
+	return CheckoutBranchChoice(BranchChoice{BranchDetails: BranchDetails{Name: str.TrimSpace(string(previous))}})
 }
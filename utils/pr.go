@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	neturl "net/url"
 	"os/exec"
 	"strings"
+
 	"github.com/pkg/browser"
 )
 
@@ -14,11 +18,14 @@ import (
 //   https://github.com/{owner}/{repo}/compare/{base}...{head}?expand=1
 //
 // Parameters:
+//   - config: The configuration used to split the branch name into its parts
 //   - base: The target branch to merge into (e.g., "main", "develop")
 //   - head: The source branch containing changes (e.g., "feature/aric/user-auth")
 //
 // URL Query Parameters:
 //   - expand=1: Automatically expands the comparison view
+//   - title: Generated from the branch type and name (see BuildPrTitle)
+//   - body: Links the issue the branch was started from, if any
 //   - The URL format handles cross-branch comparisons
 //
 // Side Effects:
@@ -28,7 +35,7 @@ import (
 //
 // Example URL:
 //   https://github.com/myorg/myproject/compare/develop...feature/aric/user-auth?expand=1
-func CreatePr(base string, head string) {
+func CreatePr(config *YamlConfig, base string, head string) {
 	repo, err := GetRepository()
 	if err != nil {
 		Errorf("Failed to get repository information: %v", err)
@@ -39,6 +46,16 @@ func CreatePr(base string, head string) {
 	// Example: https://github.com/owner/repo/compare/base...head?expand=1
 	url := fmt.Sprintf("https://github.com/%s/compare/%s...%s?expand=1", repo, base, head)
 
+	// Prefill the title from the branch name parts
+	if title := BuildPrTitle(config, head); title != "" {
+		url += "&title=" + neturl.QueryEscape(title)
+	}
+
+	// Link the issue the branch was started from in the PR body
+	if issue := GetBranchIssue(head); issue != nil {
+		url += "&body=" + neturl.QueryEscape(BuildPrBody(issue))
+	}
+
 	// Open the URL in the default browser
 	err = browser.OpenURL(url)
 	if err != nil {
@@ -49,6 +66,47 @@ func CreatePr(base string, head string) {
 	}
 }
 
+// prTitleTypes maps branch types to conventional commit types used in PR titles.
+var prTitleTypes = map[string]string{
+	"feature": "feat",
+}
+
+// BuildPrTitle generates a pull request title from a branch name.
+// The branch is split with the branch template; the issue title is preferred
+// over the branch name when the branch was started from an issue.
+//
+// Parameters:
+//   - config: The configuration containing the branch template
+//   - branch: The head branch name
+//
+// Returns:
+//   - string: The title, or empty string if the branch does not follow the template
+//
+// Examples:
+//   - "feature/aric/user-auth" -> "feat: user auth"
+//   - "fix/aric/42-login-crash" started from issue #42 -> "fix: Login crash (#42)"
+func BuildPrTitle(config *YamlConfig, branch string) string {
+	parts, ok := ParseBranchName(config, branch)
+	if !ok {
+		return ""
+	}
+
+	titleType := parts.Type
+	if mapped, found := prTitleTypes[titleType]; found {
+		titleType = mapped
+	}
+
+	if issue := GetBranchIssue(branch); issue != nil && issue.Title != "" {
+		return fmt.Sprintf("%s: %s (#%s)", titleType, issue.Title, issue.ID)
+	}
+
+	name := strings.NewReplacer("-", " ", "_", " ").Replace(parts.Name)
+	if parts.Issue != "" {
+		return fmt.Sprintf("%s: %s (#%s)", titleType, name, parts.Issue)
+	}
+	return fmt.Sprintf("%s: %s", titleType, name)
+}
+
 // SyncProductionToDev synchronizes the production branch with the development branch.
 // This function performs a complete sync operation to ensure the development branch
 // contains all changes from the production branch, typically done before starting
@@ -178,3 +236,245 @@ func containsImportantOutput(output string) bool {
 		   strings.Contains(output, "insertion") ||
 		   strings.Contains(output, "deletion")
 }
+
+// PullRequestStatus describes the state of a single pull request as reported
+// by the hosting provider.
+type PullRequestStatus struct {
+	// Number is the pull request number (e.g., 42 for #42)
+	Number int `json:"number"`
+
+	// Title is the pull request title
+	Title string `json:"title"`
+
+	// URL is the web URL of the pull request
+	URL string `json:"url"`
+
+	// State is the pull request state: OPEN, CLOSED or MERGED
+	State string `json:"state"`
+
+	// IsDraft indicates whether the pull request is still a draft
+	IsDraft bool `json:"isDraft"`
+
+	// ReviewDecision is APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED or empty
+	ReviewDecision string `json:"reviewDecision"`
+
+	// Checks is the rolled-up CI state: SUCCESS, FAILURE, PENDING or empty
+	Checks string `json:"checks"`
+
+	// Mergeable is MERGEABLE, CONFLICTING or UNKNOWN
+	Mergeable string `json:"mergeable"`
+}
+
+// BranchPullRequest pairs a local branch with its pull request, if any.
+type BranchPullRequest struct {
+	// Branch is the local branch name
+	Branch string `json:"branch"`
+
+	// Current indicates whether the branch is checked out
+	Current bool `json:"current"`
+
+	// PullRequest is the latest pull request for the branch, nil if none exists
+	PullRequest *PullRequestStatus `json:"pullRequest"`
+
+	// Error is the reason the lookup failed (e.g., a rate limit), empty on success
+	Error string `json:"error,omitempty"`
+}
+
+// ghCheck mirrors a single entry of the statusCheckRollup field returned by gh.
+// Check runs report Status/Conclusion, while commit statuses only report State.
+type ghCheck struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	State      string `json:"state"`
+}
+
+// ghPullRequest mirrors the JSON fields requested from 'gh pr list'.
+type ghPullRequest struct {
+	Number            int       `json:"number"`
+	Title             string    `json:"title"`
+	URL               string    `json:"url"`
+	State             string    `json:"state"`
+	IsDraft           bool      `json:"isDraft"`
+	ReviewDecision    string    `json:"reviewDecision"`
+	Mergeable         string    `json:"mergeable"`
+	StatusCheckRollup []ghCheck `json:"statusCheckRollup"`
+}
+
+// GetPullRequestForBranch looks up the most recent pull request whose head is
+// the given branch. It queries the hosting API through the GitHub CLI.
+//
+// Parameters:
+//   - branch: The head branch name (e.g., "feature/aric/user-auth")
+//
+// Returns:
+//   - *PullRequestStatus: The pull request, or nil if the branch has none
+//   - error: Error if gh is missing or the API request fails
+func GetPullRequestForBranch(branch string) (*PullRequestStatus, error) {
+	if !IsCommandAvailable("gh") {
+		return nil, fmt.Errorf("gh cli is not installed")
+	}
+
+	output, err := exec.Command("gh", "pr", "list",
+		"--head", branch,
+		"--state", "all",
+		"--limit", "1",
+		"--json", "number,title,url,state,isDraft,reviewDecision,mergeable,statusCheckRollup",
+	).Output()
+	if err != nil {
+		// Report gh's own message (e.g., "API rate limit exceeded") when there is one
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to query pull requests for %s: %s", branch, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to query pull requests for %s: %w", branch, err)
+	}
+
+	var prs []ghPullRequest
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request data: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+
+	pr := prs[0]
+	return &PullRequestStatus{
+		Number:         pr.Number,
+		Title:          pr.Title,
+		URL:            pr.URL,
+		State:          pr.State,
+		IsDraft:        pr.IsDraft,
+		ReviewDecision: pr.ReviewDecision,
+		Checks:         summarizeChecks(pr.StatusCheckRollup),
+		Mergeable:      pr.Mergeable,
+	}, nil
+}
+
+// GetBranchPullRequests collects pull request information for a set of branches.
+// Branches without a pull request are still included with a nil PullRequest.
+// A failed lookup does not stop the others, its error is stored in the entry.
+//
+// Parameters:
+//   - branches: Local branch names to look up
+//   - current: The currently checked out branch, used to flag its entry
+//
+// Returns:
+//   - []BranchPullRequest: One entry per branch, in input order
+func GetBranchPullRequests(branches []string, current string) []BranchPullRequest {
+	var result []BranchPullRequest
+	for _, branch := range branches {
+		entry := BranchPullRequest{
+			Branch:  branch,
+			Current: branch == current,
+		}
+		pr, err := GetPullRequestForBranch(branch)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.PullRequest = pr
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// GetPullRequestsByBranch lists the recent pull requests of the repository
+// with a single 'gh pr list' call and keys them by head branch. Only Number,
+// Title, URL, State and IsDraft are filled in.
+//
+// Returns:
+//   - map[string]*PullRequestStatus: Head branch name to its latest pull request
+//   - error: Error if gh is missing or the API request fails
+func GetPullRequestsByBranch() (map[string]*PullRequestStatus, error) {
+	if !IsCommandAvailable("gh") {
+		return nil, fmt.Errorf("gh cli is not installed")
+	}
+
+	output, err := exec.Command("gh", "pr", "list",
+		"--state", "all",
+		"--limit", "200",
+		"--json", "number,title,url,state,isDraft,headRefName",
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pull requests: %w", err)
+	}
+
+	var prs []struct {
+		ghPullRequest
+		HeadRefName string `json:"headRefName"`
+	}
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request data: %w", err)
+	}
+
+	result := map[string]*PullRequestStatus{}
+	for _, pr := range prs {
+		if _, found := result[pr.HeadRefName]; found {
+			// gh lists the newest pull requests first
+			continue
+		}
+		result[pr.HeadRefName] = &PullRequestStatus{
+			Number:  pr.Number,
+			Title:   pr.Title,
+			URL:     pr.URL,
+			State:   pr.State,
+			IsDraft: pr.IsDraft,
+		}
+	}
+	return result, nil
+}
+
+// summarizeChecks rolls the individual check results up into a single state.
+// Any failure wins over pending checks, and pending checks win over success.
+//
+// Returns:
+//   - string: FAILURE, PENDING, SUCCESS, or empty when there are no checks
+func summarizeChecks(checks []ghCheck) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	pending := false
+	for _, check := range checks {
+		result := check.Conclusion
+		if result == "" {
+			result = check.State
+		}
+
+		switch result {
+		case "FAILURE", "ERROR", "CANCELLED", "TIMED_OUT", "ACTION_REQUIRED", "STARTUP_FAILURE":
+			return "FAILURE"
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+			// Completed without problems
+		default:
+			// Queued, in progress, expected or pending
+			pending = true
+		}
+	}
+
+	if pending {
+		return "PENDING"
+	}
+	return "SUCCESS"
+}
+
+// BranchHasNickname reports whether a branch name carries the given nickname
+// as one of its path segments (e.g., "feature/aric/login" for "aric").
+//
+// Parameters:
+//   - branch: The branch name to inspect
+//   - nickname: The developer nickname from configuration
+//
+// Returns:
+//   - bool: true if the nickname is non-empty and appears as a segment
+func BranchHasNickname(branch, nickname string) bool {
+	if nickname == "" {
+		return false
+	}
+	for _, part := range strings.Split(branch, "/") {
+		if part == nickname {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"path"
+)
+
+// defaultReleaseBranchPattern matches the release branches created by 'gfl release'.
+const defaultReleaseBranchPattern = "releases/*"
+
+// ProtectedBranchPatterns returns the glob patterns of branches that gfl
+// refuses to delete, rename, push or rebase without --force-protected.
+// Without protectedBranches in the config, productionBranch, devBaseBranch
+// and the release branches are protected.
+//
+// Parameters:
+//   - config: The YAML configuration
+//
+// Returns:
+//   - []string: The patterns (e.g., ["main", "dev", "releases/*"])
+func ProtectedBranchPatterns(config *YamlConfig) []string {
+	if config.ProtectedBranches != nil {
+		return config.ProtectedBranches
+	}
+
+	var patterns []string
+	for _, branch := range []string{config.ProductionBranch, config.DevBaseBranch} {
+		if branch != "" {
+			patterns = append(patterns, branch)
+		}
+	}
+	return append(patterns, defaultReleaseBranchPattern)
+}
+
+// IsProtectedBranch reports whether a branch matches one of the protected
+// branch patterns. Patterns use path.Match syntax, so "*" does not match "/".
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - branch: The branch name without remote (e.g., "main", "releases/release-v1.2.0")
+//
+// Returns:
+//   - string: The matching pattern
+//   - bool: true if the branch is protected
+func IsProtectedBranch(config *YamlConfig, branch string) (string, bool) {
+	for _, pattern := range ProtectedBranchPatterns(config) {
+		if MatchBranchGlob(pattern, branch) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// MatchBranchGlob reports whether a branch name matches a glob pattern.
+// Patterns use path.Match syntax, so "*" matches within one path segment
+// ("feature/*" matches "feature/login" but not "feature/aric/login").
+//
+// Parameters:
+//   - pattern: The glob pattern (e.g., "releases/*", "feature/*/wip-*")
+//   - branch: The branch name without remote
+//
+// Returns:
+//   - bool: true if the pattern equals or matches the branch name
+func MatchBranchGlob(pattern, branch string) bool {
+	if pattern == branch {
+		return true
+	}
+	matched, err := path.Match(pattern, branch)
+	return err == nil && matched
+}
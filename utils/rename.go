@@ -64,7 +64,7 @@ func HandleRemoteBranch(oldBranch string, newBranch string, deleteOld bool, conf
 func DeleteRemoteBranch(branch string, confirm bool) error {
 	command := fmt.Sprintf("git push origin --delete %s", branch)
 	if confirm {
-		if err := RunCommandWithSpin(command, strings.GetPath("rename.deleting_remote")); err != nil {
+		if err := RunTrashedDelete(branch, true, command, strings.GetPath("rename.deleting_remote")); err != nil {
 			return fmt.Errorf(strings.GetPath("rename.delete_remote_error", branch, err))
 		}
 		Successf(strings.GetPath("rename.delete_remote_success", branch))
@@ -0,0 +1,192 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+// illegalRefChars contains the characters git does not allow anywhere in a ref name.
+const illegalRefChars = " ~^:?*[\\"
+
+// SanitizeBranchName cleans up a branch name so that it is accepted by
+// 'git check-ref-format --branch', applying the configured transliteration
+// and maximum length.
+//
+// Cleaning rules:
+//   - Chinese characters are converted to pinyin ("pinyin") or an ASCII slug ("slug")
+//   - Whitespace, control characters, ~ ^ : ? * [ \ and "@{" are replaced with "-"
+//   - ".." is collapsed to "." and empty path components ("//") are removed
+//   - Components may not start with "." or end with "." or ".lock"
+//   - The name is truncated to branchMaxLength characters when configured
+//
+// Parameters:
+//   - config: The configuration containing branchTransliterate and branchMaxLength
+//   - name: The branch name to clean (e.g., "feature/aric/用户 登录")
+//
+// Returns:
+//   - string: The cleaned branch name
+//   - error: Error if nothing valid is left after cleaning
+//
+// Examples:
+//   - "feature/aric/fix login: crash" -> "feature/aric/fix-login-crash"
+//   - "feature/aric/v1..2.lock" -> "feature/aric/v1.2"
+//   - "feature/aric/用户 登录" with "pinyin" -> "feature/aric/yong-hu-deng-lu"
+func SanitizeBranchName(config *YamlConfig, name string) (string, error) {
+	cleaned := transliterate(name, config.BranchTransliterate)
+	cleaned = replaceIllegalRefChars(cleaned)
+	cleaned = cleanRefComponents(cleaned)
+
+	if config.BranchMaxLength > 0 && utf8.RuneCountInString(cleaned) > config.BranchMaxLength {
+		cleaned = cleanRefComponents(string([]rune(cleaned)[:config.BranchMaxLength]))
+	}
+
+	if err := ValidateBranchName(cleaned); err != nil {
+		return "", err
+	}
+	return cleaned, nil
+}
+
+// ValidateBranchName checks a branch name against the rules of
+// 'git check-ref-format --branch'.
+//
+// Parameters:
+//   - name: The branch name to check
+//
+// Returns:
+//   - error: Error describing the first rule the name breaks, nil if valid
+func ValidateBranchName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("branch name is empty")
+	case name == "@" || name == "HEAD":
+		return fmt.Errorf("%q is not a valid branch name", name)
+	case strings.HasPrefix(name, "-"):
+		return fmt.Errorf("branch name %q cannot start with '-'", name)
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/"):
+		return fmt.Errorf("branch name %q cannot start or end with '/'", name)
+	case strings.HasSuffix(name, "."):
+		return fmt.Errorf("branch name %q cannot end with '.'", name)
+	case strings.Contains(name, ".."):
+		return fmt.Errorf("branch name %q cannot contain '..'", name)
+	case strings.Contains(name, "//"):
+		return fmt.Errorf("branch name %q cannot contain '//'", name)
+	case strings.Contains(name, "@{"):
+		return fmt.Errorf("branch name %q cannot contain '@{'", name)
+	}
+
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(illegalRefChars, r) {
+			return fmt.Errorf("branch name %q cannot contain %q", name, r)
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") {
+			return fmt.Errorf("branch name %q has a component starting with '.'", name)
+		}
+		if strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("branch name %q has a component ending with '.lock'", name)
+		}
+	}
+
+	return nil
+}
+
+// transliterate converts non-ASCII text according to the configured mode.
+//
+// Supported modes:
+//   - "pinyin": Chinese characters become pinyin syllables separated by "-"
+//   - "slug": like "pinyin", then every path component is reduced to a
+//     lowercase ASCII slug (see Slugify)
+//   - "none" or empty: the text is kept as-is
+func transliterate(s, mode string) string {
+	if mode != "pinyin" && mode != "slug" {
+		return s
+	}
+
+	args := pinyin.NewArgs()
+	var b strings.Builder
+	// Syllables are separated from each other and from adjacent letters or digits
+	prevWord, prevSyllable := false, false
+	for _, r := range s {
+		if !unicode.Is(unicode.Han, r) {
+			isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+			if isWord && prevSyllable {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			prevWord, prevSyllable = isWord, false
+			continue
+		}
+
+		py := pinyin.SinglePinyin(r, args)
+		if len(py) == 0 {
+			continue
+		}
+		if prevWord {
+			b.WriteByte('-')
+		}
+		b.WriteString(py[0])
+		prevWord, prevSyllable = true, true
+	}
+	result := b.String()
+
+	if mode == "slug" {
+		components := strings.Split(result, "/")
+		for i, component := range components {
+			components[i] = Slugify(component)
+		}
+		result = strings.Join(components, "/")
+	}
+	return result
+}
+
+// replaceIllegalRefChars replaces every run of characters git does not allow
+// in ref names with a single "-".
+func replaceIllegalRefChars(s string) string {
+	s = strings.ReplaceAll(s, "@{", "-")
+
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range s {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(illegalRefChars, r) {
+			pendingHyphen = true
+			continue
+		}
+		if pendingHyphen && b.Len() > 0 && !strings.HasSuffix(b.String(), "-") && r != '-' && r != '/' {
+			b.WriteByte('-')
+		}
+		pendingHyphen = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// cleanRefComponents fixes the per-component rules of ref names: empty
+// components are removed, ".." is collapsed and leading dots, trailing dots,
+// trailing ".lock" and stray hyphens are trimmed.
+func cleanRefComponents(s string) string {
+	for strings.Contains(s, "..") {
+		s = strings.ReplaceAll(s, "..", ".")
+	}
+
+	var components []string
+	for _, component := range strings.Split(s, "/") {
+		for {
+			trimmed := strings.Trim(component, ".-")
+			trimmed = strings.TrimSuffix(trimmed, ".lock")
+			if trimmed == component {
+				break
+			}
+			component = trimmed
+		}
+		if component != "" {
+			components = append(components, component)
+		}
+	}
+	return strings.Join(components, "/")
+}
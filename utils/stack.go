@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"fmt"
+	gflstrings "gfl/utils/strings"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+const (
+	// stackParentKey stores the parent branch name in branch.<name>.gfl-parent
+	stackParentKey = "gfl-parent"
+
+	// stackParentSHAKey stores the parent tip the branch was last based on
+	stackParentSHAKey = "gfl-parent-sha"
+)
+
+// StackBranch represents a branch tracked in a stack together with its descendants.
+type StackBranch struct {
+	// Name is the branch name
+	Name string
+
+	// Parent is the recorded parent branch name (empty for stack roots)
+	Parent string
+
+	// ParentSHA is the parent tip the branch was created from or last restacked onto
+	ParentSHA string
+
+	// Children contains the branches stacked directly on this branch
+	Children []*StackBranch
+}
+
+// RecordBranchParent stores the stack relationship of a newly created branch.
+// The parent tip is recorded as well, so a later restack knows which commits
+// belong to the parent and can drop them once the parent is rewritten or merged.
+//
+// Parameters:
+//   - branch: The new branch name
+//   - parent: The parent branch name (e.g., "dev" or "feature/aric/api")
+//   - parentRef: The revision the branch was created from (e.g., "origin/dev")
+//
+// Returns:
+//   - error: Error if the metadata cannot be written
+func RecordBranchParent(branch, parent, parentRef string) error {
+	sha, err := GetCommitSHA(parentRef)
+	if err != nil {
+		return err
+	}
+	if err := SetBranchConfig(branch, stackParentKey, parent); err != nil {
+		return err
+	}
+	return SetBranchConfig(branch, stackParentSHAKey, sha)
+}
+
+// CopyBranchParent gives a branch the same stack parent and fork point as another.
+//
+// Parameters:
+//   - from: The branch whose stack metadata is copied
+//   - to: The branch that receives the metadata
+//
+// Returns:
+//   - error: Error if the metadata cannot be written
+func CopyBranchParent(from, to string) error {
+	if err := SetBranchConfig(to, stackParentKey, GetBranchParent(from)); err != nil {
+		return err
+	}
+	return SetBranchConfig(to, stackParentSHAKey, GetBranchParentSHA(from))
+}
+
+// GetBranchParent returns the recorded stack parent of a branch.
+//
+// Returns:
+//   - string: The parent branch name, or empty string if none is recorded
+func GetBranchParent(branch string) string {
+	return GetBranchConfig(branch, stackParentKey)
+}
+
+// GetBranchParentSHA returns the parent tip recorded for a branch.
+//
+// Returns:
+//   - string: The commit SHA, or empty string if none is recorded
+func GetBranchParentSHA(branch string) string {
+	return GetBranchConfig(branch, stackParentSHAKey)
+}
+
+// GetStackParents returns the recorded parent of every local branch that has one.
+// Entries for branches that no longer exist locally are skipped.
+//
+// Returns:
+//   - map[string]string: Branch name to parent branch name
+func GetStackParents() map[string]string {
+	parents := GetBranchConfigs(stackParentKey)
+	for branch := range parents {
+		if !LocalBranchExists(branch) {
+			delete(parents, branch)
+		}
+	}
+	return parents
+}
+
+// BuildStackTree arranges the recorded parent relationships into trees.
+// Every parent that is not itself tracked (typically the dev base branch)
+// becomes a root node.
+//
+// Parameters:
+//   - parents: Branch name to parent branch name, as returned by GetStackParents
+//
+// Returns:
+//   - []*StackBranch: Root nodes sorted by name, children sorted by name
+func BuildStackTree(parents map[string]string) []*StackBranch {
+	nodes := map[string]*StackBranch{}
+	node := func(name string) *StackBranch {
+		if n, ok := nodes[name]; ok {
+			return n
+		}
+		n := &StackBranch{Name: name}
+		nodes[name] = n
+		return n
+	}
+
+	for branch, parent := range parents {
+		child := node(branch)
+		child.Parent = parent
+		child.ParentSHA = GetBranchParentSHA(branch)
+		p := node(parent)
+		p.Children = append(p.Children, child)
+	}
+
+	var roots []*StackBranch
+	for name, n := range nodes {
+		if _, tracked := parents[name]; !tracked {
+			roots = append(roots, n)
+		}
+		sort.Slice(n.Children, func(i, j int) bool { return n.Children[i].Name < n.Children[j].Name })
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Name < roots[j].Name })
+
+	return roots
+}
+
+// StackParentRef returns the revision that represents a parent branch.
+// Shared base branches are always taken from the remote, because the local
+// copies are rarely kept up to date; stacked feature branches use the local
+// branch when it exists.
+//
+// Parameters:
+//   - config: The configuration containing the base branch names
+//   - parent: The parent branch name
+//
+// Returns:
+//   - string: The revision to rebase onto (e.g., "origin/dev" or "feature/aric/api")
+func StackParentRef(config *YamlConfig, parent string) string {
+	if parent == config.DevBaseBranch || parent == config.ProductionBranch {
+		return "origin/" + parent
+	}
+	if LocalBranchExists(parent) {
+		return parent
+	}
+	return "origin/" + parent
+}
+
+// IsStackParentMerged reports whether a stacked parent has been merged away.
+// A parent counts as merged when its branch is gone both locally and on the
+// remote, or when it was merged into a base branch (see CheckBranchMerged).
+// A parent with no commits beyond its own recorded fork point is never merged:
+// it was just created or pushed, and its tip being in dev says nothing.
+//
+// Parameters:
+//   - config: The configuration containing the base branch names
+//   - parent: The parent branch name
+//
+// Returns:
+//   - bool: true if descendants should be moved to the next ancestor
+func IsStackParentMerged(config *YamlConfig, parent string) bool {
+	if parent == config.DevBaseBranch || parent == config.ProductionBranch {
+		return false
+	}
+
+	ref := StackParentRef(config, parent)
+	if _, err := GetCommitSHA(ref); err != nil {
+		return true
+	}
+	if forkPoint := GetBranchParentSHA(parent); forkPoint != "" {
+		if ahead, _, err := CountAheadBehind(ref, forkPoint); err != nil || ahead == 0 {
+			return false
+		}
+	}
+	if LocalBranchExists(parent) {
+		_, merged := CheckBranchMerged(config, parent)
+		return merged
+	}
+	return isMergedInto(ref, "origin/"+config.DevBaseBranch)
+}
+
+// ResolveStackParent walks up the recorded parents until it finds one that has
+// not been merged yet, falling back to the dev base branch.
+//
+// Parameters:
+//   - config: The configuration containing the base branch names
+//   - parents: Branch name to parent branch name
+//   - branch: The branch whose effective parent should be resolved
+//
+// Returns:
+//   - string: The parent branch the branch should be stacked on now
+func ResolveStackParent(config *YamlConfig, parents map[string]string, branch string) string {
+	parent := parents[branch]
+	seen := map[string]bool{branch: true}
+	for parent != "" && IsStackParentMerged(config, parent) {
+		if seen[parent] {
+			break
+		}
+		seen[parent] = true
+		parent = GetBranchConfig(parent, stackParentKey)
+	}
+	if parent == "" {
+		return config.DevBaseBranch
+	}
+	return parent
+}
+
+// NeedsRestack reports whether a branch is no longer based on its parent's tip.
+//
+// Parameters:
+//   - config: The configuration containing the base branch names
+//   - branch: The stacked branch
+//
+// Returns:
+//   - bool: true if the parent has moved since the branch was last based on it
+func NeedsRestack(config *YamlConfig, branch StackBranch) bool {
+	parentSHA, err := GetCommitSHA(StackParentRef(config, branch.Parent))
+	if err != nil {
+		return true
+	}
+	return parentSHA != branch.ParentSHA || !IsAncestor(parentSHA, branch.Name)
+}
+
+// RestackBranch rebases a stacked branch onto the current tip of its parent.
+// Only the commits after the recorded parent tip are replayed, so commits that
+// were rewritten or squash-merged in the parent are not duplicated.
+//
+// Parameters:
+//   - config: The configuration containing the base branch names
+//   - branch: The branch to rebase
+//   - parent: The parent branch to rebase onto
+//
+// Returns:
+//   - error: Error if the rebase fails (the rebase is left in progress for the user)
+func RestackBranch(config *YamlConfig, branch, parent string) error {
+	parentRef := StackParentRef(config, parent)
+
+	// Fall back to the merge base if no parent tip was recorded
+	upstream := GetBranchParentSHA(branch)
+	if upstream == "" {
+		output, err := exec.Command("git", "merge-base", parentRef, branch).Output()
+		if err != nil {
+			return fmt.Errorf("failed to find merge base of %s and %s: %w", branch, parentRef, err)
+		}
+		upstream = strings.TrimSpace(string(output))
+	}
+
+	command := fmt.Sprintf("git rebase --onto %s %s %s", parentRef, upstream, branch)
+	if err := RunCommandWithSpin(command, gflstrings.GetPath("stack.restacking", branch, parentRef)); err != nil {
+		return err
+	}
+
+	return RecordBranchParent(branch, parent, parentRef)
+}
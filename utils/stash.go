@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Autostash modes, see YamlConfig.Autostash.
+const (
+	// AutostashOff leaves a dirty working directory alone
+	AutostashOff = "off"
+
+	// AutostashCarry stashes the changes before switching and applies them
+	// again on the target branch
+	AutostashCarry = "carry"
+
+	// AutostashBranch stashes the changes for the branch being left, they are
+	// applied again when switching back to it
+	AutostashBranch = "branch"
+)
+
+// autostashPrefix tags the stashes created by gfl
+const autostashPrefix = "gfl-autostash: "
+
+// IsValidAutostashMode reports whether mode is one of the Autostash* modes.
+//
+// Parameters:
+//   - mode: The mode to check
+//
+// Returns:
+//   - bool: true if the mode is valid
+func IsValidAutostashMode(mode string) bool {
+	return mode == AutostashOff || mode == AutostashCarry || mode == AutostashBranch
+}
+
+// StashChanges stashes the uncommitted changes (including untracked files)
+// with a gfl-tagged message naming the branch they belong to.
+//
+// Parameters:
+//   - branch: The branch the changes belong to
+//
+// Returns:
+//   - bool: true if changes were stashed, false if the working directory is clean
+//   - error: Error if git stash fails
+func StashChanges(branch string) (bool, error) {
+	if isWorkingDirectoryClean() {
+		return false, nil
+	}
+	output, err := exec.Command("git", "stash", "push", "--include-untracked", "-m", autostashPrefix+branch).CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return true, nil
+}
+
+// FindAutostash finds the most recent gfl stash of a branch.
+//
+// Parameters:
+//   - branch: The branch the changes belong to
+//
+// Returns:
+//   - string: The stash ref (e.g., "stash@{0}")
+//   - bool: false if the branch has no gfl stash
+func FindAutostash(branch string) (string, bool) {
+	output, err := exec.Command("git", "stash", "list", "--format=%gd%x00%gs").Output()
+	if err != nil {
+		return "", false
+	}
+	// Stash subjects read "On <branch>: <message>"
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		ref, subject, found := strings.Cut(line, "\x00")
+		if found && strings.HasSuffix(subject, ": "+autostashPrefix+branch) {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+// PopAutostash applies the most recent gfl stash of a branch to the working
+// directory and drops it. When the changes conflict, git keeps the stash and
+// leaves conflict markers in the working directory.
+//
+// Parameters:
+//   - branch: The branch the changes belong to
+//
+// Returns:
+//   - string: The stash ref, empty if the branch has no gfl stash
+//   - error: Error if the stash cannot be applied cleanly
+func PopAutostash(branch string) (string, error) {
+	ref, found := FindAutostash(branch)
+	if !found {
+		return "", nil
+	}
+	output, err := exec.Command("git", "stash", "pop", "--index", ref).CombinedOutput()
+	if err != nil {
+		// --index fails when the staged changes no longer apply, retry without it
+		if isWorkingDirectoryClean() {
+			output, err = exec.Command("git", "stash", "pop", ref).CombinedOutput()
+		}
+		if err != nil {
+			return ref, fmt.Errorf("%s", strings.TrimSpace(string(output)))
+		}
+	}
+	return ref, nil
+}
+
+// IsRebaseInProgress reports whether a rebase stopped, e.g. on conflicts.
+//
+// Returns:
+//   - bool: true if a rebase is in progress
+func IsRebaseInProgress() bool {
+	return exec.Command("git", "rev-parse", "-q", "--verify", "REBASE_HEAD").Run() == nil
+}
@@ -0,0 +1,513 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// How a merged branch was detected, from the most to the least reliable.
+const (
+	// MergeKindMerge means the branch tip is reachable from the base branch
+	// (merge commit or fast-forward)
+	MergeKindMerge = "merge"
+
+	// MergeKindRebase means every commit of the branch has a patch-equivalent
+	// commit in the base branch
+	MergeKindRebase = "rebase"
+
+	// MergeKindSquash means the combined diff of the branch matches a single
+	// commit of the base branch
+	MergeKindSquash = "squash"
+
+	// MergeKindContent means merging the branch into the base branch would
+	// not change anything, the changes are already there in some form
+	MergeKindContent = "content"
+)
+
+// Confidence that a branch is merged, see MergeKind*.
+const (
+	MergeConfidenceCertain = "certain"
+	MergeConfidenceHigh    = "high"
+	MergeConfidenceMedium  = "medium"
+)
+
+// MergedBranch is a branch whose commits are already contained in a base branch.
+type MergedBranch struct {
+	// Name is the branch name without the remote (e.g., "feature/aric/login")
+	Name string
+
+	// Base is the base branch it was merged into (e.g., "dev" or "origin/dev")
+	Base string
+
+	// MergeCommit is the abbreviated SHA of the commit on Base that brought
+	// the branch in: the merge commit, the branch tip after a fast-forward,
+	// or the rebased or squashed commit (empty for MergeKindContent)
+	MergeCommit string
+
+	// MergeSubject is the subject line of MergeCommit
+	MergeSubject string
+
+	// Kind is how the merge was detected (MergeKindMerge, MergeKindSquash, ...)
+	Kind string
+
+	// Confidence is MergeConfidenceCertain for real merges, lower for
+	// branches detected by comparing patches or trees
+	Confidence string
+}
+
+// GetMergedBranches lists the branches fully merged into devBaseBranch or
+// productionBranch. Branches found by 'git branch --merged' are certain;
+// the others are compared patch by patch with the base branch to find
+// rebase and squash merges, which 'git branch --merged' cannot see.
+// The base branches themselves are not listed. Comparing patches is the
+// expensive part, so callers that filter branches pass the remaining names.
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - remote: true to check the origin remote branches against origin/<base>,
+//     false to check local branches against the local base (or origin/<base>
+//     when the base is not checked out)
+//   - names: The branches (without remote) to check for rebase and squash
+//     merges, nil for every branch
+//
+// Returns:
+//   - map[string]MergedBranch: Branch name (without remote) to merge information
+//   - error: Error if no base branch exists or git fails
+func GetMergedBranches(config *YamlConfig, remote bool, names []string) (map[string]MergedBranch, error) {
+	bases := mergedBaseRefs(config, remote)
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("neither %s nor %s exists", config.DevBaseBranch, config.ProductionBranch)
+	}
+
+	result := map[string]MergedBranch{}
+	isBase := func(name string) bool {
+		return name == "HEAD" || name == config.DevBaseBranch || name == config.ProductionBranch
+	}
+	// Branches without commits of their own are contained in the base
+	// branch without having been merged
+	fresh := map[string]bool{}
+	isFresh := func(name, ref string) bool {
+		if _, checked := fresh[name]; !checked {
+			fresh[name] = hasNoOwnCommits(config, name, ref)
+		}
+		return fresh[name]
+	}
+	for _, base := range bases {
+		args := []string{"branch", "--merged", base, "--format=%(refname)"}
+		prefix := "refs/heads/"
+		if remote {
+			args = []string{"branch", "-r", "--merged", base, "--format=%(refname)"}
+			prefix = "refs/remotes/origin/"
+		}
+		output, err := exec.Command("git", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches merged into %s: %w", base, err)
+		}
+
+		for _, line := range strings.Split(string(output), "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, prefix) {
+				// Other remotes
+				continue
+			}
+			name := strings.TrimPrefix(line, prefix)
+			if isBase(name) || isFresh(name, line) {
+				continue
+			}
+			if _, found := result[name]; found {
+				// Already found in devBaseBranch, which is checked first
+				continue
+			}
+
+			merged := MergedBranch{Name: name, Base: base, Kind: MergeKindMerge, Confidence: MergeConfidenceCertain}
+			merged.MergeCommit, merged.MergeSubject = findMergeCommit(strings.TrimPrefix(line, "refs/"), base)
+			result[name] = merged
+		}
+	}
+
+	// Branches that are not ancestors may still have been rebased or squashed
+	// into a base branch
+	refs, err := branchRefs(remote)
+	if err != nil {
+		return nil, err
+	}
+	if names != nil {
+		candidates := map[string]string{}
+		for _, name := range names {
+			if ref, found := refs[name]; found {
+				candidates[name] = ref
+			}
+		}
+		refs = candidates
+	}
+	for _, base := range bases {
+		var pending []string
+		for name, ref := range refs {
+			if _, found := result[name]; !found && !isBase(name) && !isFresh(name, ref) {
+				pending = append(pending, ref)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		index := newPatchIndex(base, pending)
+		for name, ref := range refs {
+			if _, found := result[name]; found || isBase(name) || isFresh(name, ref) {
+				continue
+			}
+			if merged, ok := index.detect(ref); ok {
+				merged.Name = name
+				result[name] = merged
+			}
+		}
+	}
+	return result, nil
+}
+
+// CheckBranchMerged checks whether a single local branch is merged into
+// devBaseBranch or productionBranch. Besides the local base branches, their
+// origin copies are checked too, as they often contain merges made on the
+// server that were not pulled yet.
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - branch: The local branch name (e.g., "feature/aric/login")
+//
+// Returns:
+//   - MergedBranch: The merge information
+//   - bool: true if the branch is merged
+func CheckBranchMerged(config *YamlConfig, branch string) (MergedBranch, bool) {
+	ref := "refs/heads/" + branch
+	if hasNoOwnCommits(config, branch, ref) {
+		return MergedBranch{}, false
+	}
+	bases := mergedBaseRefs(config, false)
+	for _, base := range mergedBaseRefs(config, true) {
+		if !containsString(bases, base) {
+			bases = append(bases, base)
+		}
+	}
+
+	for _, base := range bases {
+		if exec.Command("git", "merge-base", "--is-ancestor", ref, base).Run() == nil {
+			merged := MergedBranch{Name: branch, Base: base, Kind: MergeKindMerge, Confidence: MergeConfidenceCertain}
+			merged.MergeCommit, merged.MergeSubject = findMergeCommit("heads/"+branch, base)
+			return merged, true
+		}
+	}
+	for _, base := range bases {
+		if merged, ok := detectPatchMerge(ref, base); ok {
+			merged.Name = branch
+			return merged, true
+		}
+	}
+	return MergedBranch{}, false
+}
+
+// hasNoOwnCommits reports whether a branch still points at the commit it was
+// created from: the fork point recorded by 'gfl start' (see RecordBranchParent)
+// or, for local branches created from a base branch, the first entry of the
+// branch reflog. Such a branch is contained in its base branch without having
+// been merged. Without a known fork point the branch is assumed to have commits.
+func hasNoOwnCommits(config *YamlConfig, name, ref string) bool {
+	forkPoint := GetBranchParentSHA(name)
+	if forkPoint == "" && strings.HasPrefix(ref, "refs/heads/") {
+		forkPoint = branchCreationPoint(config, name)
+	}
+	if forkPoint == "" {
+		return false
+	}
+	ahead, _, err := CountAheadBehind(ref, forkPoint)
+	return err == nil && ahead == 0
+}
+
+// branchCreationPoint reads the commit a local branch was created at from its
+// reflog. Branches created from another feature branch (e.g., a teammate's
+// branch checked out from origin) are skipped, their tip is not their fork point.
+func branchCreationPoint(config *YamlConfig, name string) string {
+	output, err := exec.Command("git", "reflog", "show", "--format=%H%x00%gs", "refs/heads/"+name, "--").Output()
+	if err != nil || len(output) == 0 {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	sha, subject, _ := strings.Cut(lines[len(lines)-1], "\x00")
+	from, found := strings.CutPrefix(subject, "branch: Created from ")
+	if !found {
+		// The reflog no longer reaches back to the creation
+		return ""
+	}
+	for _, base := range []string{"HEAD", config.DevBaseBranch, config.ProductionBranch, "origin/" + config.DevBaseBranch, "origin/" + config.ProductionBranch} {
+		if from == base {
+			return sha
+		}
+	}
+	return ""
+}
+
+// branchRefs maps the local (or origin remote) branch names to their full refs.
+func branchRefs(remote bool) (map[string]string, error) {
+	prefix := "refs/heads/"
+	if remote {
+		prefix = "refs/remotes/origin/"
+	}
+	output, err := exec.Command("git", "for-each-ref", "--format=%(refname)", prefix).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	refs := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			refs[strings.TrimPrefix(line, prefix)] = line
+		}
+	}
+	return refs, nil
+}
+
+// detectPatchMerge checks whether a single branch that is not an ancestor of
+// base was merged by rebase or squash (see patchIndex.detect).
+//
+// Parameters:
+//   - branch: The branch ref (e.g., "refs/heads/feature/aric/login")
+//   - base: The base ref (e.g., "dev" or "origin/dev")
+//
+// Returns:
+//   - MergedBranch: The merge information without Name
+//   - bool: true if the branch is merged
+func detectPatchMerge(branch, base string) (MergedBranch, bool) {
+	return newPatchIndex(base, []string{branch}).detect(branch)
+}
+
+// patchIndex holds the patches of the commits a base branch gained since a
+// set of branches forked from it, read with a single 'git log -p' of the base
+// branch, so that many branches can be checked for rebase and squash merges.
+type patchIndex struct {
+	// base is the base ref (e.g., "dev" or "origin/dev")
+	base string
+
+	// forkPoints maps each branch ref to its merge base with base; branches
+	// with unrelated histories are missing
+	forkPoints map[string]string
+
+	// patches maps stable patch IDs to the base commits carrying them
+	patches map[string][]string
+
+	// tree is the tree of base, read on first use
+	tree string
+}
+
+// newPatchIndex reads the patches base gained since any of the branches forked.
+func newPatchIndex(base string, branches []string) *patchIndex {
+	index := &patchIndex{base: base, forkPoints: map[string]string{}, patches: map[string][]string{}}
+	seen := map[string]bool{}
+	var forkPoints []string
+	for _, branch := range branches {
+		output, err := exec.Command("git", "merge-base", base, branch).Output()
+		if err != nil {
+			// Unrelated histories
+			continue
+		}
+		forkPoint := strings.TrimSpace(string(output))
+		index.forkPoints[branch] = forkPoint
+		if !seen[forkPoint] {
+			seen[forkPoint] = true
+			forkPoints = append(forkPoints, forkPoint)
+		}
+	}
+	if len(forkPoints) == 0 {
+		return index
+	}
+
+	// Excluding every fork point would drop the commits base gained after the
+	// older ones; exclude only their common ancestor, lookup skips the commits
+	// a branch already had
+	oldest := forkPoints[0]
+	if len(forkPoints) > 1 {
+		output, err := exec.Command("git", append([]string{"merge-base", "--octopus"}, forkPoints...)...).Output()
+		if err != nil {
+			return index
+		}
+		oldest = strings.TrimSpace(string(output))
+	}
+	index.patches = patchIDs("log", "-p", "--no-merges", "--format=commit %H", oldest+".."+base)
+	return index
+}
+
+// detect checks whether a branch that is not an ancestor of base was merged
+// by rebase or squash, by looking for its patches among the commits base
+// gained since they forked. As a last resort the trees are compared: if
+// merging the branch would leave base unchanged, the changes are already
+// there (medium confidence, e.g., squashed together with others).
+func (index *patchIndex) detect(branch string) (MergedBranch, bool) {
+	forkPoint, found := index.forkPoints[branch]
+	if !found {
+		return MergedBranch{}, false
+	}
+	merged := MergedBranch{Base: index.base, Confidence: MergeConfidenceHigh}
+
+	// Rebase: every commit of the branch has a patch-equivalent commit in base
+	branchPatches := patchIDs("log", "-p", "--no-merges", "--format=commit %H", forkPoint+".."+branch)
+	if len(branchPatches) > 0 {
+		tip, _ := GetCommitSHA(branch)
+		rebased, tipCommit := true, ""
+		for id, commits := range branchPatches {
+			baseCommit, found := index.lookup(id, forkPoint)
+			if !found {
+				rebased = false
+				break
+			}
+			if containsString(commits, tip) || tipCommit == "" {
+				tipCommit = baseCommit
+			}
+		}
+		if rebased {
+			merged.Kind = MergeKindRebase
+			merged.MergeCommit, merged.MergeSubject = describeCommit(tipCommit)
+			return merged, true
+		}
+	}
+
+	// Squash: the whole branch as one patch
+	for id := range patchIDs("diff", forkPoint, branch) {
+		if commit, found := index.lookup(id, forkPoint); found {
+			merged.Kind = MergeKindSquash
+			merged.MergeCommit, merged.MergeSubject = describeCommit(commit)
+			return merged, true
+		}
+	}
+
+	// Content: a merge would not change the base tree
+	mergedTree, err := exec.Command("git", "merge-tree", "--write-tree", index.base, branch).Output()
+	if err != nil {
+		// Conflicts, or git older than 2.38
+		return MergedBranch{}, false
+	}
+	if index.tree == "" {
+		baseTree, err := exec.Command("git", "rev-parse", index.base+"^{tree}").Output()
+		if err != nil {
+			return MergedBranch{}, false
+		}
+		index.tree = strings.TrimSpace(string(baseTree))
+	}
+	if strings.TrimSpace(string(mergedTree)) != index.tree {
+		return MergedBranch{}, false
+	}
+	merged.Kind = MergeKindContent
+	merged.Confidence = MergeConfidenceMedium
+	return merged, true
+}
+
+// lookup finds the base commit carrying a patch. The index covers the commits
+// since the common ancestor of all fork points, so commits the branch already
+// had are skipped.
+func (index *patchIndex) lookup(id, forkPoint string) (string, bool) {
+	for _, commit := range index.patches[id] {
+		if !IsAncestor(commit, forkPoint) {
+			return commit, true
+		}
+	}
+	return "", false
+}
+
+// patchIDs runs a git command that prints patches and maps their stable patch
+// IDs to the commits they belong to ("git diff" patches map to an empty SHA).
+func patchIDs(args ...string) map[string][]string {
+	ids := map[string][]string{}
+	patches, err := exec.Command("git", args...).Output()
+	if err != nil || len(patches) == 0 {
+		return ids
+	}
+
+	cmd := exec.Command("git", "patch-id", "--stable")
+	cmd.Stdin = bytes.NewReader(patches)
+	output, err := cmd.Output()
+	if err != nil {
+		return ids
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		id, commit, found := strings.Cut(line, " ")
+		if found {
+			ids[id] = append(ids[id], commit)
+		}
+	}
+	return ids
+}
+
+// describeCommit returns the abbreviated SHA and subject of a commit.
+func describeCommit(commit string) (string, string) {
+	if commit == "" {
+		return "", ""
+	}
+	info, err := exec.Command("git", "show", "-s", "--format=%h%x00%s", commit).Output()
+	if err != nil {
+		return "", ""
+	}
+	sha, subject, _ := strings.Cut(strings.TrimSpace(string(info)), "\x00")
+	return sha, subject
+}
+
+// IsKeptBranch reports whether a branch matches one of the sweepKeep patterns.
+//
+// Parameters:
+//   - config: The YAML configuration
+//   - branch: The branch name without remote
+//
+// Returns:
+//   - bool: true if sweep must leave the branch alone
+func IsKeptBranch(config *YamlConfig, branch string) bool {
+	for _, pattern := range config.SweepKeep {
+		if MatchBranchGlob(pattern, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergedBaseRefs returns the existing refs of devBaseBranch and productionBranch.
+func mergedBaseRefs(config *YamlConfig, remote bool) []string {
+	var refs []string
+	for _, branch := range []string{config.DevBaseBranch, config.ProductionBranch} {
+		if ref := baseRef(branch, remote); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// findMergeCommit finds the commit on base that brought branch in: the merge
+// commit having the branch tip as a parent, or the tip itself when the branch
+// was fast-forwarded.
+//
+// Returns:
+//   - string: The abbreviated commit SHA
+//   - string: The commit subject
+func findMergeCommit(branch, base string) (string, string) {
+	tip, err := GetCommitSHA(branch)
+	if err != nil {
+		return "", ""
+	}
+
+	commit := tip
+	output, err := exec.Command("git", "rev-list", "--ancestry-path", "--merges", "--reverse", "--format=%P", tip+".."+base).Output()
+	if err == nil {
+		// Each commit is printed as "commit <sha>" followed by its parents
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		for i := 0; i+1 < len(lines); i += 2 {
+			parents := strings.Fields(lines[i+1])
+			if len(parents) > 1 && containsString(parents[1:], tip) {
+				commit = strings.TrimPrefix(lines[i], "commit ")
+				break
+			}
+		}
+	}
+
+	info, err := exec.Command("git", "show", "-s", "--format=%h%x00%s", commit).Output()
+	if err != nil {
+		return "", ""
+	}
+	sha, subject, _ := strings.Cut(strings.TrimSpace(string(info)), "\x00")
+	return sha, subject
+}
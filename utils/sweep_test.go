@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepo creates a repository with a "dev" branch in a temporary
+// directory and makes it the working directory of the test.
+func newTestRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for key, value := range map[string]string{
+		"GIT_AUTHOR_NAME":     "Test",
+		"GIT_AUTHOR_EMAIL":    "test@example.com",
+		"GIT_COMMITTER_NAME":  "Test",
+		"GIT_COMMITTER_EMAIL": "test@example.com",
+		"GIT_CONFIG_GLOBAL":   filepath.Join(dir, ".gitconfig"),
+		"GIT_CONFIG_NOSYSTEM": "1",
+	} {
+		t.Setenv(key, value)
+	}
+	runGit(t, "init", "-q", "-b", "dev")
+	commitFile(t, "README.md", "readme")
+}
+
+// runGit runs a git command in the test repository.
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+// commitFile writes a file and commits it on the current branch.
+func commitFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, "add", name)
+	runGit(t, "commit", "-q", "-m", "add "+name)
+}
+
+func TestGetMergedBranchesSquashBeforeOtherForkPoint(t *testing.T) {
+	newTestRepo(t)
+
+	// feature/aric/squashed forks first and is squash-merged into dev
+	runGit(t, "checkout", "-q", "-b", "feature/aric/squashed")
+	commitFile(t, "a.txt", "a")
+	commitFile(t, "b.txt", "b")
+	runGit(t, "checkout", "-q", "dev")
+	runGit(t, "merge", "-q", "--squash", "feature/aric/squashed")
+	runGit(t, "commit", "-q", "-m", "squashed")
+
+	// feature/aric/other forks after the squash commit
+	runGit(t, "checkout", "-q", "-b", "feature/aric/other")
+	commitFile(t, "c.txt", "c")
+	runGit(t, "checkout", "-q", "dev")
+
+	config := &YamlConfig{DevBaseBranch: "dev", ProductionBranch: "main"}
+	for _, names := range [][]string{nil, {"feature/aric/squashed"}} {
+		merged, err := GetMergedBranches(config, false, names)
+		if err != nil {
+			t.Fatal(err)
+		}
+		squashed, found := merged["feature/aric/squashed"]
+		if !found {
+			t.Fatalf("names %v: feature/aric/squashed not reported as merged", names)
+		}
+		if squashed.Kind != MergeKindSquash || squashed.Confidence != MergeConfidenceHigh {
+			t.Errorf("names %v: got %s/%s, want %s/%s", names, squashed.Kind, squashed.Confidence, MergeKindSquash, MergeConfidenceHigh)
+		}
+		if _, found := merged["feature/aric/other"]; found {
+			t.Errorf("names %v: feature/aric/other reported as merged", names)
+		}
+	}
+}
+
+func TestGetMergedBranchesSkipsBranchesWithoutOwnCommits(t *testing.T) {
+	newTestRepo(t)
+
+	// feature/aric/fresh was just created from dev
+	runGit(t, "branch", "feature/aric/fresh")
+
+	// feature/aric/done has a commit and was merged into dev
+	runGit(t, "checkout", "-q", "-b", "feature/aric/done")
+	commitFile(t, "done.txt", "done")
+	runGit(t, "checkout", "-q", "dev")
+	runGit(t, "merge", "-q", "--no-ff", "-m", "merge done", "feature/aric/done")
+
+	config := &YamlConfig{DevBaseBranch: "dev", ProductionBranch: "main"}
+	merged, err := GetMergedBranches(config, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := merged["feature/aric/fresh"]; found {
+		t.Error("feature/aric/fresh reported as merged")
+	}
+	if done, found := merged["feature/aric/done"]; !found || done.Kind != MergeKindMerge {
+		t.Errorf("feature/aric/done: got %+v, want a %s merge", done, MergeKindMerge)
+	}
+	if _, isMerged := CheckBranchMerged(config, "feature/aric/fresh"); isMerged {
+		t.Error("CheckBranchMerged reports feature/aric/fresh as merged")
+	}
+}
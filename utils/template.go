@@ -0,0 +1,308 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBranchTemplate is used when no branchTemplate is configured.
+// It reproduces the classic "{prefix}/{nickname}/{name}" naming.
+const DefaultBranchTemplate = "{type}/{nickname}/{name}"
+
+// branchDateFormat is the layout of the {date} placeholder (YYYYMMDD).
+const branchDateFormat = "20060102"
+
+// templatePlaceholders lists the supported placeholders and the pattern each
+// one matches when a branch name is parsed back into its parts.
+var templatePlaceholders = map[string]string{
+	"type":     `[^/]+`,
+	"nickname": `[^/]+`,
+	"name":     `.+`,
+	"issue":    `[A-Z][A-Z0-9]*-\d+|\d+`,
+	"date":     `\d{8}`,
+	"user":     `[^/]+`,
+}
+
+// templateSeparators are the characters that are dropped together with an
+// empty optional placeholder (e.g. the "/" after an empty {nickname}).
+const templateSeparators = "/-_."
+
+// placeholderPattern matches "{placeholder}" in a branch template.
+var placeholderPattern = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// BranchParts holds the components a branch name is built from.
+type BranchParts struct {
+	// Type is the branch type name (e.g., "feature", "fix", "hotfix")
+	Type string
+
+	// Nickname is the developer nickname
+	Nickname string
+
+	// Name is the descriptive part of the branch (e.g., "user-auth")
+	Name string
+
+	// Issue is the issue id the branch belongs to (e.g., "123")
+	Issue string
+
+	// Date is the creation date in YYYYMMDD format
+	Date string
+
+	// User is the slugified git user.name
+	User string
+}
+
+// templateToken is either a literal piece of a template or a placeholder.
+type templateToken struct {
+	literal     string
+	placeholder string
+}
+
+// GetBranchTemplate returns the configured branch template or the default one.
+//
+// Parameters:
+//   - config: The YAML configuration
+//
+// Returns:
+//   - string: The branch template
+func GetBranchTemplate(config *YamlConfig) string {
+	if config.BranchTemplate != "" {
+		return config.BranchTemplate
+	}
+	return DefaultBranchTemplate
+}
+
+// GenerateBranchNameFromParts renders the branch template with the given parts.
+// Missing nickname, date and user values are filled in from configuration, the
+// clock and git. The prefix, nickname and case format follow the branch type.
+// Empty optional placeholders are dropped together with the separator that
+// follows them, so "{type}/{nickname}/{name}" without a nickname renders as
+// "feature/login". The case format applies to the last path segment of the
+// rendered name (see FormatBranchName).
+//
+// Parameters:
+//   - config: The YAML configuration containing branch naming settings
+//   - parts: The branch components; Type and Name are required
+//
+// Returns:
+//   - string: The rendered branch name
+//
+// Examples:
+//   - "{type}/{issue}-{name}" with issue "42" -> "feature/42-login"
+//   - "{nickname}/{type}/{date}-{name}" -> "aric/feature/20240101-login"
+func GenerateBranchNameFromParts(config *YamlConfig, parts BranchParts) string {
+	template := GetBranchTemplate(config)
+	tokens := parseTemplate(template)
+
+	if parts.Nickname == "" {
+		parts.Nickname = config.Nickname
+	}
+	if parts.Date == "" {
+		parts.Date = time.Now().Format(branchDateFormat)
+	}
+	if parts.User == "" && strings.Contains(template, "{user}") {
+		parts.User = Slugify(GetGitUserName())
+	}
+
+	// Keep the issue id in the name when the template has no place for it
+	name := parts.Name
+	if parts.Issue != "" && !strings.Contains(template, "{issue}") {
+		name = parts.Issue + "-" + name
+	}
+
+	branchType, _ := GetBranchType(config, parts.Type)
+
+	// Some branch types (e.g., docs) leave the nickname out
+	if !branchType.Nickname {
+		parts.Nickname = ""
+	}
+
+	values := map[string]string{
+		"type":     branchType.Prefix,
+		"nickname": parts.Nickname,
+		"name":     name,
+		"issue":    parts.Issue,
+		"date":     parts.Date,
+		"user":     parts.User,
+	}
+
+	var b strings.Builder
+	dropSeparator := false
+	for _, token := range tokens {
+		if token.placeholder == "" {
+			literal := token.literal
+			if dropSeparator && literal != "" && strings.ContainsRune(templateSeparators, rune(literal[0])) {
+				literal = literal[1:]
+			}
+			dropSeparator = false
+			b.WriteString(literal)
+			continue
+		}
+
+		value := values[token.placeholder]
+		if value == "" {
+			dropSeparator = true
+			continue
+		}
+		dropSeparator = false
+		b.WriteString(value)
+	}
+
+	// Apply the type's case format to the last segment only, as the classic
+	// naming did, so the prefix and nickname are preserved
+	return FormatBranchName(strings.Trim(b.String(), templateSeparators), branchType.CaseFormat)
+}
+
+// ParseBranchName splits an existing branch name back into its parts using
+// the configured branch template. The branch type is resolved from its prefix.
+//
+// Parameters:
+//   - config: The YAML configuration containing the branch template and prefixes
+//   - branch: The branch name to parse (e.g., "feature/aric/login")
+//
+// Returns:
+//   - *BranchParts: The parsed components
+//   - bool: false if the branch does not follow the template
+//
+// Examples:
+//   - "feature/aric/login" -> Type "feature", Nickname "aric", Name "login"
+//   - "main" -> nil, false
+func ParseBranchName(config *YamlConfig, branch string) (*BranchParts, bool) {
+	re, err := branchTemplateRegexp(config)
+	if err != nil {
+		return nil, false
+	}
+
+	matches := re.FindStringSubmatch(branch)
+	if matches == nil {
+		return nil, false
+	}
+
+	parts := &BranchParts{}
+	for i, group := range re.SubexpNames() {
+		if group == "" || matches[i] == "" {
+			continue
+		}
+		switch group {
+		case "type":
+			parts.Type = branchTypeForPrefix(config, matches[i])
+		case "nickname":
+			parts.Nickname = matches[i]
+		case "name":
+			parts.Name = matches[i]
+		case "issue":
+			parts.Issue = matches[i]
+		case "date":
+			parts.Date = matches[i]
+		case "user":
+			parts.User = matches[i]
+		}
+	}
+
+	if parts.Name == "" {
+		return nil, false
+	}
+	return parts, true
+}
+
+// parseTemplate splits a branch template into literal and placeholder tokens.
+// Unknown placeholders are kept as literal text.
+func parseTemplate(template string) []templateToken {
+	var tokens []templateToken
+	last := 0
+	for _, loc := range placeholderPattern.FindAllStringSubmatchIndex(template, -1) {
+		name := template[loc[2]:loc[3]]
+		if _, known := templatePlaceholders[name]; !known {
+			continue
+		}
+		if loc[0] > last {
+			tokens = append(tokens, templateToken{literal: template[last:loc[0]]})
+		}
+		tokens = append(tokens, templateToken{placeholder: name})
+		last = loc[1]
+	}
+	if last < len(template) {
+		tokens = append(tokens, templateToken{literal: template[last:]})
+	}
+	return tokens
+}
+
+// branchTemplateRegexp builds the regular expression used to parse branch names.
+// It mirrors GenerateBranchNameFromParts: every placeholder except {type} and
+// {name} is optional together with the separator that follows it.
+func branchTemplateRegexp(config *YamlConfig) (*regexp.Regexp, error) {
+	tokens := parseTemplate(GetBranchTemplate(config))
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.placeholder == "" {
+			b.WriteString(regexp.QuoteMeta(token.literal))
+			continue
+		}
+
+		pattern := templatePlaceholders[token.placeholder]
+		switch token.placeholder {
+		case "type":
+			pattern = branchPrefixPattern(config)
+		case "name":
+			// Non-greedy so trailing optional placeholders can still match
+			pattern = `.+?`
+		}
+		group := fmt.Sprintf("(?P<%s>%s)", token.placeholder, pattern)
+
+		if token.placeholder == "type" || token.placeholder == "name" {
+			b.WriteString(group)
+			continue
+		}
+
+		// Optional placeholder: swallow the separator that is dropped with it,
+		// which is the following one, or the preceding one at the very end
+		leading, trailing := "", ""
+		if i+1 < len(tokens) && tokens[i+1].placeholder == "" {
+			next := tokens[i+1].literal
+			if next != "" && strings.ContainsRune(templateSeparators, rune(next[0])) {
+				trailing = next[:1]
+				tokens[i+1].literal = next[1:]
+			}
+		} else if i+1 == len(tokens) && i > 0 && tokens[i-1].placeholder == "" {
+			prev := tokens[i-1].literal
+			if prev != "" && strings.ContainsRune(templateSeparators, rune(prev[len(prev)-1])) {
+				leading = prev[len(prev)-1:]
+				current := b.String()
+				quoted := regexp.QuoteMeta(leading)
+				b.Reset()
+				b.WriteString(strings.TrimSuffix(current, quoted))
+			}
+		}
+		b.WriteString("(?:" + regexp.QuoteMeta(leading) + group + regexp.QuoteMeta(trailing) + ")?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// branchPrefixPattern matches any configured branch prefix, preferring the
+// longest one so prefixes containing "/" are recognized, or any single segment.
+func branchPrefixPattern(config *YamlConfig) string {
+	var prefixes []string
+	for _, branchType := range GetBranchTypes(config) {
+		prefixes = append(prefixes, regexp.QuoteMeta(branchType.Prefix))
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+	return strings.Join(prefixes, "|") + `|[^/]+`
+}
+
+// branchTypeForPrefix maps a branch prefix back to its branch type name.
+// Unknown prefixes are returned as-is, matching GetBranchTypePrefix.
+func branchTypeForPrefix(config *YamlConfig, prefix string) string {
+	for _, branchType := range GetBranchTypes(config) {
+		if branchType.Prefix == prefix {
+			return branchType.Name
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,302 @@
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// trashPairWindow is how close the deletions of a local branch and its
+	// remote copy are, when sweep deletes both together
+	trashPairWindow = time.Minute
+
+	// trashRefPrefix holds the tips of deleted branches, one ref per deletion:
+	// refs/gfl/trash/<unix time>/<local|remote>/<branch>
+	trashRefPrefix = "refs/gfl/trash/"
+
+	// trashConfigSection stores the upstream of deleted local branches in
+	// git config, keyed like the ref: gfl-trash.<unix time>/local/<branch>.upstream
+	trashConfigSection = "gfl-trash"
+)
+
+// TrashEntry is a deleted branch kept in the branch trash.
+type TrashEntry struct {
+	// ID identifies the entry: "<unix time>/<local|remote>/<branch>"
+	ID string
+
+	// Branch is the branch name without the remote
+	Branch string
+
+	// Remote is true for branches deleted from origin
+	Remote bool
+
+	// SHA is the tip of the branch when it was deleted
+	SHA string
+
+	// Subject is the subject line of the tip commit
+	Subject string
+
+	// Upstream is the upstream of a deleted local branch (e.g., "origin/feature/aric/login")
+	Upstream string
+
+	// DeletedAt is when the branch was deleted
+	DeletedAt time.Time
+}
+
+// TrashBranch records the tip of a branch that is about to be deleted, so
+// 'gfl trash restore' can bring it back. Call DropTrash if the deletion fails.
+//
+// Parameters:
+//   - branch: The branch name without the remote
+//   - remote: true for a branch deleted from origin
+//
+// Returns:
+//   - TrashEntry: The recorded entry
+//   - error: Error if the branch cannot be resolved or the ref cannot be written
+func TrashBranch(branch string, remote bool) (TrashEntry, error) {
+	ref, scope := "refs/heads/"+branch, "local"
+	if remote {
+		ref, scope = "refs/remotes/origin/"+branch, "remote"
+	}
+	sha, err := GetCommitSHA(ref)
+	if err != nil {
+		return TrashEntry{}, err
+	}
+
+	now := time.Now()
+	entry := TrashEntry{
+		ID:        fmt.Sprintf("%d/%s/%s", now.Unix(), scope, branch),
+		Branch:    branch,
+		Remote:    remote,
+		SHA:       sha,
+		DeletedAt: now,
+	}
+	if err := exec.Command("git", "update-ref", "-m", "gfl: delete "+branch, trashRefPrefix+entry.ID, sha).Run(); err != nil {
+		return TrashEntry{}, fmt.Errorf("failed to record %s in the trash: %w", branch, err)
+	}
+
+	if !remote {
+		// for-each-ref also reports an upstream that is gone
+		if upstream, err := exec.Command("git", "for-each-ref", "--format=%(upstream:short)", ref).Output(); err == nil && len(strings.TrimSpace(string(upstream))) > 0 {
+			entry.Upstream = strings.TrimSpace(string(upstream))
+			exec.Command("git", "config", trashConfigKey(entry.ID), entry.Upstream).Run()
+		}
+	}
+	return entry, nil
+}
+
+// RunTrashedDelete records a branch in the trash and runs the command that
+// deletes it (e.g., "git branch -d feature/aric/login"). The trash entry is
+// removed again if the command fails.
+//
+// Parameters:
+//   - branch: The branch name without the remote
+//   - remote: true for a branch deleted from origin
+//   - command: The git command deleting the branch
+//   - message: The spinner message
+//
+// Returns:
+//   - error: Error if the branch cannot be recorded or the command fails
+func RunTrashedDelete(branch string, remote bool, command string, message string) error {
+	entry, err := TrashBranch(branch, remote)
+	if err != nil {
+		return err
+	}
+	if err := RunCommandWithSpin(command, message); err != nil {
+		DropTrash(entry)
+		return err
+	}
+	return nil
+}
+
+// DropTrash removes an entry from the trash, after it was restored or when
+// the deletion it recorded failed.
+//
+// Parameters:
+//   - entry: The entry to remove
+//
+// Returns:
+//   - error: Error if the ref cannot be deleted
+func DropTrash(entry TrashEntry) error {
+	if entry.Upstream != "" {
+		exec.Command("git", "config", "--unset", trashConfigKey(entry.ID)).Run()
+	}
+	if err := exec.Command("git", "update-ref", "-d", trashRefPrefix+entry.ID).Run(); err != nil {
+		return fmt.Errorf("failed to remove %s from the trash: %w", entry.Branch, err)
+	}
+	return nil
+}
+
+// ListTrash lists the deleted branches in the trash, most recent first.
+//
+// Returns:
+//   - []TrashEntry: The entries
+//   - error: Error if git fails
+func ListTrash() ([]TrashEntry, error) {
+	output, err := exec.Command("git", "for-each-ref", "--format=%(refname)%00%(objectname)%00%(subject)", trashRefPrefix).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the trash: %w", err)
+	}
+	upstreams := trashUpstreams()
+
+	var entries []TrashEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		id := strings.TrimPrefix(fields[0], trashRefPrefix)
+		parts := strings.SplitN(id, "/", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		unix, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, TrashEntry{
+			ID:        id,
+			Branch:    parts[2],
+			Remote:    parts[1] == "remote",
+			SHA:       fields[1],
+			Subject:   fields[2],
+			Upstream:  upstreams[id],
+			DeletedAt: time.Unix(unix, 0),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].DeletedAt.After(entries[j].DeletedAt) })
+	return entries, nil
+}
+
+// FindTrash returns the most recently deleted copy of a branch, preferring
+// the local copy when the local and remote branches were deleted together
+// (within trashPairWindow).
+//
+// Parameters:
+//   - branch: The branch name without the remote
+//
+// Returns:
+//   - TrashEntry: The entry
+//   - bool: false if the branch is not in the trash
+func FindTrash(branch string) (TrashEntry, bool) {
+	entries, err := ListTrash()
+	if err != nil {
+		return TrashEntry{}, false
+	}
+	var found *TrashEntry
+	for i, entry := range entries {
+		if entry.Branch != branch {
+			continue
+		}
+		if found == nil {
+			found = &entries[i]
+		} else if found.Remote && !entry.Remote && isTrashPair(*found, entry) {
+			found = &entries[i]
+			break
+		}
+	}
+	if found == nil {
+		return TrashEntry{}, false
+	}
+	return *found, true
+}
+
+// isTrashPair reports whether two entries are the local and remote copies of
+// a branch deleted together.
+func isTrashPair(a, b TrashEntry) bool {
+	gap := a.DeletedAt.Sub(b.DeletedAt)
+	return a.Branch == b.Branch && a.Remote != b.Remote && gap < trashPairWindow && gap > -trashPairWindow
+}
+
+// RestoreTrash recreates a deleted branch locally at its recorded tip and
+// removes it from the trash. The recorded upstream is set again if it exists.
+// When the branch is pushed again, the remote copy deleted together with it
+// is removed from the trash as well.
+//
+// Parameters:
+//   - entry: The entry to restore
+//   - push: true to push the branch to origin again and track it
+//
+// Returns:
+//   - error: Error if the branch already exists or git fails
+func RestoreTrash(entry TrashEntry, push bool) error {
+	if LocalBranchExists(entry.Branch) {
+		return fmt.Errorf("branch %s already exists", entry.Branch)
+	}
+	if output, err := exec.Command("git", "branch", entry.Branch, entry.SHA).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to recreate %s: %s", entry.Branch, strings.TrimSpace(string(output)))
+	}
+
+	if push {
+		if output, err := exec.Command("git", "push", "-u", "origin", entry.Branch).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to push %s: %s", entry.Branch, strings.TrimSpace(string(output)))
+		}
+		if entries, err := ListTrash(); err == nil {
+			for _, other := range entries {
+				if isTrashPair(entry, other) {
+					DropTrash(other)
+				}
+			}
+		}
+	} else if entry.Upstream != "" {
+		// The upstream may have been deleted as well
+		exec.Command("git", "branch", "--set-upstream-to", entry.Upstream, entry.Branch).Run()
+	}
+
+	return DropTrash(entry)
+}
+
+// PurgeTrash permanently removes the entries deleted longer ago than the given age.
+//
+// Parameters:
+//   - olderThan: The minimum age of purged entries, 0 purges everything
+//
+// Returns:
+//   - []TrashEntry: The purged entries
+//   - error: Error if the trash cannot be read or an entry cannot be removed
+func PurgeTrash(olderThan time.Duration) ([]TrashEntry, error) {
+	entries, err := ListTrash()
+	if err != nil {
+		return nil, err
+	}
+	var purged []TrashEntry
+	for _, entry := range entries {
+		if time.Since(entry.DeletedAt) < olderThan {
+			continue
+		}
+		if err := DropTrash(entry); err != nil {
+			return purged, err
+		}
+		purged = append(purged, entry)
+	}
+	return purged, nil
+}
+
+// trashConfigKey returns the git config key holding the upstream of an entry.
+func trashConfigKey(id string) string {
+	return fmt.Sprintf("%s.%s.upstream", trashConfigSection, id)
+}
+
+// trashUpstreams reads the recorded upstreams of all entries.
+func trashUpstreams() map[string]string {
+	result := map[string]string{}
+	output, err := exec.Command("git", "config", "--get-regexp", `^`+trashConfigSection+`\..*\.upstream$`).Output()
+	if err != nil {
+		// git exits with 1 when nothing matches
+		return result
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		key, value, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(key, trashConfigSection+"."), ".upstream")
+		result[id] = value
+	}
+	return result
+}